@@ -0,0 +1,142 @@
+package scanio
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAddress is returned by ParseAddress for a malformed address spec.
+var ErrInvalidAddress = errors.New("scanio: invalid address")
+
+// addrRange is one item of an address list.
+type addrRange struct {
+	first, last int
+	toEnd       bool // range ends with the last token
+	onlyLast    bool // "$" address
+	step        int
+}
+
+func (r addrRange) contains(num int, isLast bool) bool {
+	switch {
+	case r.onlyLast:
+		return isLast
+	case r.step > 0:
+		return num >= r.first && (num-r.first)%r.step == 0
+	case num < r.first:
+		return false
+	case r.toEnd:
+		return true
+	}
+	return num <= r.last
+}
+
+// Address is a set of token numbers, parsed from a sed/awk-like spec.
+type Address struct {
+	ranges []addrRange
+}
+
+// ParseAddress parses a comma-separated list of addresses. Each address can be:
+//
+//	N          single token number, counted from 1
+//	N-M        range of token numbers, inclusive
+//	N-$        range from N to the last token
+//	$          the last token
+//	first~step every step-th token, starting with the first (first can be 0)
+//
+// Example: "1,10-20,50-$".
+func ParseAddress(spec string) (*Address, error) {
+	a := &Address{}
+	for _, part := range strings.Split(spec, ",") {
+		r, err := parseAddrRange(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAddress, part)
+		}
+		a.ranges = append(a.ranges, r)
+	}
+	return a, nil
+}
+
+func parseAddrRange(s string) (addrRange, error) {
+	if s == "$" {
+		return addrRange{onlyLast: true}, nil
+	}
+	if first, step, ok := strings.Cut(s, "~"); ok {
+		f, err := strconv.Atoi(first)
+		if err != nil {
+			return addrRange{}, err
+		}
+		st, err := strconv.Atoi(step)
+		if err != nil {
+			return addrRange{}, err
+		}
+		if f < 0 || st < 1 {
+			return addrRange{}, ErrInvalidAddress
+		}
+		return addrRange{first: f, step: st}, nil
+	}
+	first, last, isRange := strings.Cut(s, "-")
+	f, err := strconv.Atoi(first)
+	if err != nil {
+		return addrRange{}, err
+	}
+	if f < 1 {
+		return addrRange{}, ErrInvalidAddress
+	}
+	if !isRange {
+		return addrRange{first: f, last: f}, nil
+	}
+	if last == "$" {
+		return addrRange{first: f, toEnd: true}, nil
+	}
+	l, err := strconv.Atoi(last)
+	if err != nil {
+		return addrRange{}, err
+	}
+	if l < f {
+		return addrRange{}, ErrInvalidAddress
+	}
+	return addrRange{first: f, last: l}, nil
+}
+
+// Contains returns true if the address selects a token number num.
+// isLast tells whether the token is the last one, for the "$" address.
+func (a *Address) Contains(num int, isLast bool) bool {
+	for _, r := range a.ranges {
+		if r.contains(num, isLast) {
+			return true
+		}
+	}
+	return false
+}
+
+//--------------------------------------------------------------------------------
+
+type addressScanner struct {
+	AheadScanner
+	addr  *Address
+	match bool
+}
+
+// NewAddressScanner returns a Scanner that marks tokens selected by the address as matching.
+// Tokens are selected by their NumRead value.
+func NewAddressScanner(sc Scanner, addr *Address) Scanner {
+	return Scanner(&addressScanner{
+		AheadScanner: NewAheadScanner(sc),
+		addr:         addr,
+	})
+}
+
+func (sc *addressScanner) Scan() bool {
+	if sc.AheadScanner.Scan() {
+		sc.match = sc.addr.Contains(sc.AheadScanner.NumRead(), sc.AheadScanner.IsLast())
+		return true
+	}
+	sc.match = false
+	return false
+}
+
+func (sc *addressScanner) IsMatch() bool {
+	return sc.match
+}
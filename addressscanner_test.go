@@ -0,0 +1,102 @@
+package scanio_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func TestParseAddressInvalid(t *testing.T) {
+	for _, spec := range []string{"", "0", "a", "5-3", "1-", "-2", "1~0", "1~", "$-3", "1,,2"} {
+		if _, err := scanio.ParseAddress(spec); !errors.Is(err, scanio.ErrInvalidAddress) {
+			t.Errorf("%q: should be %v, is %v", spec, scanio.ErrInvalidAddress, err)
+		}
+	}
+}
+
+func TestAddressContains(t *testing.T) {
+	addr, err := scanio.ParseAddress("2, 4-5, 0~7, 10-$, $")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := map[int]bool{1: false, 2: true, 3: false, 4: true, 5: true, 6: false, 7: true, 8: false, 9: false, 10: true, 14: true, 20: true}
+	for num, v := range expected {
+		if addr.Contains(num, false) != v {
+			t.Errorf("at %d: should be %v, is %v", num, v, !v)
+		}
+	}
+	if !addr.Contains(3, true) {
+		t.Errorf("at last: should be %v, is %v", true, false)
+	}
+}
+
+func TestAddressScanner(t *testing.T) {
+	f, err := os.Open("assets/simpleFile.txt")
+	defer f.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	addr, err := scanio.ParseAddress("2-3,9,$")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	scn := scanio.NewAddressScanner(scanio.NewScanner(f), addr)
+
+	expected := []result{
+		{true, 1, false, "this is a simple file"},
+		{true, 2, true, "next line is empty"},
+		{true, 3, true, ""},
+		{true, 4, false, "next line has two spaces"},
+		{true, 5, false, "  "},
+		{true, 6, false, "# bash-like comment"},
+		{true, 7, false, "line with two trailing spaces  "},
+		{true, 8, false, " line with one leading space"},
+		{true, 9, true, " line with one leading and one trailing space "},
+		{true, 10, false, "# bash-like comment 2 "},
+		{true, 11, true, "last line"},
+		{false, 11, false, ""},
+		{false, 11, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestAddressScannerOnlyNotMatch(t *testing.T) {
+	f, err := os.Open("assets/simpleFile.txt")
+	defer f.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	addr, err := scanio.ParseAddress("1-9")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	scn := scanio.NewOnlyNotMatchScanner(scanio.NewAddressScanner(scanio.NewScanner(f), addr))
+
+	expected := []result{
+		{true, 10, false, "# bash-like comment 2 "},
+		{true, 11, false, "last line"},
+		{false, 11, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
@@ -21,6 +21,9 @@ type info struct {
 	IsMatch bool
 	NumRead int
 	Match   bool
+	// checkpoint of the Scanner before this token was scanned
+	cp    Checkpoint
+	cpErr error
 }
 
 func newInfo(bufLen, bufCap int) *info {
@@ -42,9 +45,7 @@ func (i *info) isConsecMatch(nextI *info) bool {
 func (i *info) update(sc Scanner, scResult bool) {
 	i.Text, i.Err, i.NumRead, i.IsMatch, i.ScanRes = sc.Text(), sc.Err(), sc.NumRead(), sc.IsMatch(), scResult
 	// preserve the underlying scanner's buffer
-	srcLen := len(sc.Bytes())
-	i.Bytes = i.Bytes[:srcLen]
-	copy(i.Bytes, sc.Bytes())
+	i.Bytes = append(i.Bytes[:0], sc.Bytes()...)
 }
 
 const (
@@ -77,14 +78,17 @@ func (sc *aheadScanner) Scan() bool {
 		sc.nextInfo = newInfo(sc.bufSize, sc.bufCap)
 
 		//scan two tokens (one ahead)
+		sc.info.cp, sc.info.cpErr = checkpointOf(sc.Scanner)
 		scanRes := sc.Scanner.Scan()
 		sc.info.update(sc.Scanner, scanRes)
+		sc.nextInfo.cp, sc.nextInfo.cpErr = checkpointOf(sc.Scanner)
 		nextScanRes := sc.Scanner.Scan()
 		sc.nextInfo.update(sc.Scanner, nextScanRes)
 
 		sc.started = true
 	} else {
 		sc.info, sc.nextInfo = sc.nextInfo, sc.info
+		sc.nextInfo.cp, sc.nextInfo.cpErr = checkpointOf(sc.Scanner)
 		nextScanRes2 := sc.Scanner.Scan()
 		sc.nextInfo.update(sc.Scanner, nextScanRes2)
 	}
@@ -0,0 +1,274 @@
+package scanio
+
+import (
+	"bufio"
+	"time"
+)
+
+// BatchScanner groups tokens into batches.
+// Batch() returns owned copies of the tokens, it is safe to keep them after the next Scan.
+type BatchScanner interface {
+	Buffer(buf []byte, max int)
+	Err() error
+	Scan() bool
+	Split(split bufio.SplitFunc)
+
+	Batch() [][]byte // tokens of the current batch
+	NumRead() int    // number of the last token in the current batch
+	IsLast() bool    // true if the current batch is the last one
+}
+
+type batchScanner struct {
+	AheadScanner
+	size, maxBytes int
+	batch          [][]byte
+	pending        []byte // token which did not fit into the previous batch
+	pendingLast    bool
+	hasPending     bool
+	eof            bool // the last token has been put into a batch
+	last           bool
+	num            int
+}
+
+// NewBatchScanner creates a BatchScanner yielding batches of size tokens.
+// The final batch can be shorter.
+func NewBatchScanner(sc Scanner, size int) BatchScanner {
+	return NewSizedBatchScanner(sc, size, 0)
+}
+
+// NewSizedBatchScanner creates a BatchScanner yielding batches of at most size tokens
+// and at most maxBytes bytes (0 means no limit).
+// A token longer than maxBytes forms a batch of its own.
+func NewSizedBatchScanner(sc Scanner, size, maxBytes int) BatchScanner {
+	if size < 1 {
+		size = 1
+	}
+	return BatchScanner(&batchScanner{
+		AheadScanner: NewAheadScanner(sc),
+		size:         size,
+		maxBytes:     maxBytes,
+	})
+}
+
+func (sc *batchScanner) Scan() bool {
+	sc.batch = make([][]byte, 0, sc.size)
+	numBytes := 0
+	if sc.hasPending {
+		sc.batch = append(sc.batch, sc.pending)
+		sc.num++
+		numBytes = len(sc.pending)
+		sc.eof = sc.pendingLast
+		sc.pending, sc.hasPending = nil, false
+	}
+	for len(sc.batch) < sc.size && !sc.eof && sc.AheadScanner.Scan() {
+		tok := append([]byte(nil), sc.AheadScanner.Bytes()...)
+		if sc.maxBytes > 0 && len(sc.batch) > 0 && numBytes+len(tok) > sc.maxBytes {
+			sc.pending, sc.pendingLast, sc.hasPending = tok, sc.AheadScanner.IsLast(), true
+			break
+		}
+		sc.batch = append(sc.batch, tok)
+		sc.num++
+		numBytes += len(tok)
+		sc.eof = sc.AheadScanner.IsLast()
+	}
+	sc.last = sc.eof && !sc.hasPending
+	if len(sc.batch) == 0 {
+		sc.batch = nil
+		sc.last = true
+		return false
+	}
+	return true
+}
+
+func (sc *batchScanner) Batch() [][]byte {
+	return sc.batch
+}
+
+func (sc *batchScanner) IsLast() bool {
+	return sc.last
+}
+
+// NumRead does not count a token held back for the next batch.
+func (sc *batchScanner) NumRead() int {
+	return sc.num
+}
+
+//--------------------------------------------------------------------------------
+
+// Clock provides timers for the NewTimedBatchScanner.
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type timedBatchScanner struct {
+	sc         Scanner
+	size       int
+	maxWait    time.Duration
+	clock      Clock
+	tokens     chan []byte
+	done       chan struct{}
+	batch      [][]byte
+	pending    []byte
+	hasPending bool
+	started    bool
+	closed     bool
+	stopped    bool
+	last       bool
+	num        int
+	err        error
+}
+
+// NewTimedBatchScanner creates a BatchScanner for follow-mode inputs, where the next token may not come soon.
+// A batch is yielded when it has size tokens, or when maxWait has elapsed since its first token.
+// The underlying Scanner is read in its own goroutine, started at the first Scan.
+// Clock can be nil, a real-time clock is used then.
+//
+// IsLast is true if the end of input was known at the time the batch was yielded.
+// If the end comes later, the next Scan just returns false.
+//
+// The returned BatchScanner is an io.Closer. If the consumer stops scanning before the end of input,
+// Close should be called to stop the reading goroutine. The goroutine ends after the pending Scan
+// of the underlying Scanner returns.
+func NewTimedBatchScanner(sc Scanner, size int, maxWait time.Duration, clock Clock) BatchScanner {
+	if size < 1 {
+		size = 1
+	}
+	if clock == nil {
+		clock = realClock{}
+	}
+	return BatchScanner(&timedBatchScanner{
+		sc:      sc,
+		size:    size,
+		maxWait: maxWait,
+		clock:   clock,
+		done:    make(chan struct{}),
+	})
+}
+
+func (sc *timedBatchScanner) read() {
+	for sc.sc.Scan() {
+		select {
+		case sc.tokens <- append([]byte(nil), sc.sc.Bytes()...):
+		case <-sc.done:
+			return
+		}
+	}
+	sc.err = sc.sc.Err()
+	close(sc.tokens)
+}
+
+// receive waits for the next token. Returns false if the input has ended.
+func (sc *timedBatchScanner) receive(timeout <-chan time.Time) (tok []byte, ok, timedOut bool) {
+	if sc.closed {
+		return nil, false, false
+	}
+	select {
+	case tok, ok = <-sc.tokens:
+		if !ok {
+			sc.closed = true
+			return nil, false, false
+		}
+		return tok, true, false
+	case <-timeout:
+		return nil, false, true
+	}
+}
+
+// tryReceive returns the next token if it is ready, without waiting.
+// Sets the closed flag if the end of input is ready.
+func (sc *timedBatchScanner) tryReceive() (tok []byte, ok bool) {
+	if sc.closed {
+		return nil, false
+	}
+	select {
+	case tok, ok = <-sc.tokens:
+		if !ok {
+			sc.closed = true
+		}
+		return tok, ok
+	default:
+		return nil, false
+	}
+}
+
+func (sc *timedBatchScanner) Scan() bool {
+	if sc.stopped {
+		sc.batch = nil
+		return false
+	}
+	if !sc.started {
+		sc.started = true
+		sc.tokens = make(chan []byte, sc.size)
+		go sc.read()
+	}
+	sc.batch = make([][]byte, 0, sc.size)
+	if sc.hasPending {
+		sc.batch = append(sc.batch, sc.pending)
+		sc.pending, sc.hasPending = nil, false
+	} else if tok, ok, _ := sc.receive(nil); ok {
+		sc.batch = append(sc.batch, tok)
+	}
+	if len(sc.batch) == 0 {
+		sc.batch = nil
+		sc.last = true
+		return false
+	}
+	sc.num++
+
+	deadline := sc.clock.After(sc.maxWait)
+	for len(sc.batch) < sc.size {
+		tok, ok, timedOut := sc.receive(deadline)
+		if !ok {
+			sc.last = !timedOut
+			return true
+		}
+		sc.batch = append(sc.batch, tok)
+		sc.num++
+	}
+	// the batch is full: look one token ahead, if it is ready
+	sc.pending, sc.hasPending = sc.tryReceive()
+	sc.last = sc.closed
+	return true
+}
+
+// Close stops the reading goroutine. The next Scan returns false.
+func (sc *timedBatchScanner) Close() error {
+	if !sc.stopped {
+		sc.stopped = true
+		close(sc.done)
+	}
+	return nil
+}
+
+func (sc *timedBatchScanner) Batch() [][]byte {
+	return sc.batch
+}
+
+func (sc *timedBatchScanner) IsLast() bool {
+	return sc.last
+}
+
+func (sc *timedBatchScanner) NumRead() int {
+	return sc.num
+}
+
+func (sc *timedBatchScanner) Err() error {
+	if sc.closed {
+		return sc.err
+	}
+	return nil
+}
+
+func (sc *timedBatchScanner) Buffer(buf []byte, max int) {
+	sc.sc.Buffer(buf, max)
+}
+
+func (sc *timedBatchScanner) Split(split bufio.SplitFunc) {
+	sc.sc.Split(split)
+}
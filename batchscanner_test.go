@@ -0,0 +1,180 @@
+package scanio_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomaskraus/scanio"
+)
+
+type resultB struct {
+	canParse bool
+	num      int
+	batch    string
+	isLast   bool
+}
+
+func batchResult(sc scanio.BatchScanner, res bool) resultB {
+	return resultB{res, sc.NumRead(), fmt.Sprintf("%q", sc.Batch()), sc.IsLast()}
+}
+
+func TestBatchScanner(t *testing.T) {
+	f := strings.NewReader("a\nb\nc\nd\ne")
+	scn := scanio.NewBatchScanner(scanio.NewScanner(f), 2)
+
+	expected := []resultB{
+		{true, 2, `["a" "b"]`, false},
+		{true, 4, `["c" "d"]`, false},
+		{true, 5, `["e"]`, true},
+		{false, 5, `[]`, true},
+		{false, 5, `[]`, true},
+	}
+	for _, v := range expected {
+		r := batchResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestBatchScannerFullLast(t *testing.T) {
+	f := strings.NewReader("a\n\nc\nd")
+	scn := scanio.NewBatchScanner(scanio.NewScanner(f), 2)
+
+	expected := []resultB{
+		{true, 2, `["a" ""]`, false},
+		{true, 4, `["c" "d"]`, true},
+		{false, 4, `[]`, true},
+	}
+	for _, v := range expected {
+		r := batchResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestBatchScannerEmpty(t *testing.T) {
+	scn := scanio.NewBatchScanner(scanio.NewScanner(strings.NewReader("")), 2)
+
+	expected := []resultB{
+		{false, 0, `[]`, true},
+		{false, 0, `[]`, true},
+	}
+	for _, v := range expected {
+		r := batchResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestSizedBatchScanner(t *testing.T) {
+	f := strings.NewReader("aa\nbb\ncccccc\nd\ne")
+	scn := scanio.NewSizedBatchScanner(scanio.NewScanner(f), 3, 5)
+
+	expected := []resultB{
+		{true, 2, `["aa" "bb"]`, false},
+		{true, 3, `["cccccc"]`, false},
+		{true, 5, `["d" "e"]`, true},
+		{false, 5, `[]`, true},
+	}
+	for _, v := range expected {
+		r := batchResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestSizedBatchScannerPendingLast(t *testing.T) {
+	f := strings.NewReader("aa\nbbbbbb")
+	scn := scanio.NewSizedBatchScanner(scanio.NewScanner(f), 3, 5)
+
+	expected := []resultB{
+		{true, 1, `["aa"]`, false},
+		{true, 2, `["bbbbbb"]`, true},
+		{false, 2, `[]`, true},
+	}
+	for _, v := range expected {
+		r := batchResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+// manualClock fires its timers on demand.
+type manualClock struct {
+	called chan struct{}
+	fire   chan time.Time
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.called <- struct{}{}
+	return c.fire
+}
+
+func TestTimedBatchScanner(t *testing.T) {
+	r, w := io.Pipe()
+	clock := &manualClock{called: make(chan struct{}, 10), fire: make(chan time.Time)}
+	scn := scanio.NewTimedBatchScanner(scanio.NewScanner(r), 2, time.Second, clock)
+
+	results := make(chan resultB)
+	scan := func() {
+		res := scn.Scan()
+		results <- batchResult(scn, res)
+	}
+
+	// the only token is followed by a timeout
+	go scan()
+	w.Write([]byte("a\n"))
+	<-clock.called
+	clock.fire <- time.Time{}
+	if r, v := <-results, (resultB{true, 1, `["a"]`, false}); r != v {
+		t.Errorf("should be %v, is %v", v, r)
+	}
+
+	// the full batch does not wait for the next token, the manual clock never fires
+	go scan()
+	w.Write([]byte("b\nc\n"))
+	if r, v := <-results, (resultB{true, 3, `["b" "c"]`, false}); r != v {
+		t.Errorf("should be %v, is %v", v, r)
+	}
+
+	// the batch is followed by the end of input
+	go scan()
+	w.Write([]byte("d\n"))
+	w.Close()
+	if r, v := <-results, (resultB{true, 4, `["d"]`, true}); r != v {
+		t.Errorf("should be %v, is %v", v, r)
+	}
+
+	go scan()
+	if r, v := <-results, (resultB{false, 4, `[]`, true}); r != v {
+		t.Errorf("should be %v, is %v", v, r)
+	}
+	if scn.Err() != nil {
+		t.Error(scn.Err())
+	}
+}
+
+func TestTimedBatchScannerClose(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	scn := scanio.NewTimedBatchScanner(scanio.NewScanner(r), 1, time.Second, nil)
+
+	go w.Write([]byte("a\nb\nc\n"))
+	if !scn.Scan() || fmt.Sprintf("%q", scn.Batch()) != `["a"]` {
+		t.Errorf("should be %v, is %q", `["a"]`, scn.Batch())
+	}
+	if err := scn.(io.Closer).Close(); err != nil {
+		t.Error(err)
+	}
+	if scn.Scan() {
+		t.Errorf("should be stopped, is %q", scn.Batch())
+	}
+}
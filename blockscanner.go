@@ -0,0 +1,123 @@
+package scanio
+
+// BlockScanner recognizes indentation-structured blocks of lines (Python, YAML).
+// A block begins with a line indented more than the previous one, and ends before a line indented less.
+// Blank lines (containing only white space) do not begin or end blocks, they belong to the current block.
+// Reads one line ahead, so the end of a block is known on its last line.
+type BlockScanner interface {
+	AheadScanner
+	Indent() int        // indentation width of the current line, tabs expanded
+	Depth() int         // number of blocks the current line is in
+	IsBlockBegin() bool // true if the current line is the first line of a block
+	IsBlockEnd() bool   // true if the current line is the last line of one or more blocks
+	NumBlockEnds() int  // number of blocks ending at the current line
+	ParentNumRead() int // NumRead of the line the current block belongs to, 0 for top-level lines
+}
+
+// block is an entry of the block stack.
+type block struct {
+	indent int
+	parent int // NumRead of the parent line
+	last   int // NumRead of the last non-blank line of the block, not counting its inner blocks
+}
+
+type blockScanner struct {
+	*aheadScanner
+	tabWidth int
+	stack    []block
+	indent   int
+	begin    bool
+	numEnds  int
+}
+
+// NewBlockScanner creates a new BlockScanner. Tabs are expanded to tabWidth columns, 8 if tabWidth is not positive.
+func NewBlockScanner(sc Scanner, tabWidth int) BlockScanner {
+	if tabWidth < 1 {
+		tabWidth = 8
+	}
+	return BlockScanner(&blockScanner{
+		aheadScanner: NewAheadScanner(sc).(*aheadScanner),
+		tabWidth:     tabWidth,
+		stack:        []block{{}},
+	})
+}
+
+// indentOf returns the indentation width of a line, and false if the line is blank.
+func (sc *blockScanner) indentOf(line []byte) (int, bool) {
+	width := 0
+	for _, b := range line {
+		switch b {
+		case ' ':
+			width++
+		case '\t':
+			width += sc.tabWidth - width%sc.tabWidth
+		case '\r', '\n', '\v', '\f':
+		default:
+			return width, true
+		}
+	}
+	return width, false
+}
+
+func (sc *blockScanner) Scan() bool {
+	// pop blocks which ended at the previous line
+	sc.stack = sc.stack[:len(sc.stack)-sc.numEnds]
+	sc.begin, sc.numEnds = false, 0
+	if !sc.aheadScanner.Scan() {
+		sc.stack = sc.stack[:1]
+		sc.indent = 0
+		return false
+	}
+
+	top := sc.stack[len(sc.stack)-1]
+	ind, ok := sc.indentOf(sc.aheadScanner.Bytes())
+	if !ok {
+		// blank line stays in the current block
+		ind = top.indent
+	}
+	if ind > top.indent {
+		// the parent is the last line of the enclosing block, as the blocks ended before are popped
+		sc.stack = append(sc.stack, block{indent: ind, parent: top.last})
+		sc.begin = true
+	}
+	sc.indent = ind
+	if ok {
+		// a blank line cannot be a parent
+		sc.stack[len(sc.stack)-1].last = sc.aheadScanner.NumRead()
+	}
+
+	nextInd := 0
+	if !sc.aheadScanner.IsLast() {
+		if nextInd, ok = sc.indentOf(sc.aheadScanner.nextInfo.Bytes); !ok {
+			nextInd = ind
+		}
+	}
+	for i := len(sc.stack) - 1; i > 0 && sc.stack[i].indent > nextInd; i-- {
+		sc.numEnds++
+	}
+	return true
+}
+
+func (sc *blockScanner) Indent() int {
+	return sc.indent
+}
+
+func (sc *blockScanner) Depth() int {
+	return len(sc.stack) - 1
+}
+
+func (sc *blockScanner) IsBlockBegin() bool {
+	return sc.begin
+}
+
+func (sc *blockScanner) IsBlockEnd() bool {
+	return sc.numEnds > 0
+}
+
+func (sc *blockScanner) NumBlockEnds() int {
+	return sc.numEnds
+}
+
+func (sc *blockScanner) ParentNumRead() int {
+	return sc.stack[len(sc.stack)-1].parent
+}
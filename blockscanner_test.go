@@ -0,0 +1,118 @@
+package scanio_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+type resultBl struct {
+	num     int
+	indent  int
+	depth   int
+	begin   bool
+	numEnds int
+	parent  int
+}
+
+func TestBlockScanner(t *testing.T) {
+	const input = `def f():
+    if x:
+        a()
+
+        b()
+    c()
+d = 1
+`
+	scn := scanio.NewBlockScanner(scanio.NewScanner(strings.NewReader(input)), 4)
+
+	expected := []resultBl{
+		{1, 0, 0, false, 0, 0},
+		{2, 4, 1, true, 0, 1},
+		{3, 8, 2, true, 0, 2},
+		{4, 8, 2, false, 0, 2},
+		{5, 8, 2, false, 1, 2},
+		{6, 4, 1, false, 1, 1},
+		{7, 0, 0, false, 0, 0},
+	}
+	for _, v := range expected {
+		if !scn.Scan() {
+			t.Errorf("should be %v, is the end", v)
+			return
+		}
+		r := resultBl{scn.NumRead(), scn.Indent(), scn.Depth(), scn.IsBlockBegin(), scn.NumBlockEnds(), scn.ParentNumRead()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+	if scn.Scan() || scn.Depth() != 0 || scn.IsBlockEnd() {
+		t.Errorf("should be the end")
+	}
+}
+
+func TestBlockScannerEndOfInput(t *testing.T) {
+	const input = "a:\n\tb:\n\t\tc\n\t  d"
+	scn := scanio.NewBlockScanner(scanio.NewScanner(strings.NewReader(input)), 4)
+
+	expected := []resultBl{
+		{1, 0, 0, false, 0, 0},
+		{2, 4, 1, true, 0, 1},
+		{3, 8, 2, true, 1, 2},
+		{4, 6, 2, true, 2, 2},
+	}
+	for _, v := range expected {
+		if !scn.Scan() {
+			t.Errorf("should be %v, is the end", v)
+			return
+		}
+		r := resultBl{scn.NumRead(), scn.Indent(), scn.Depth(), scn.IsBlockBegin(), scn.NumBlockEnds(), scn.ParentNumRead()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+		if scn.IsBlockEnd() != (v.numEnds > 0) {
+			t.Errorf("at %d: IsBlockEnd should be %v", v.num, v.numEnds > 0)
+		}
+	}
+}
+
+func TestBlockScannerBlankBeforeChild(t *testing.T) {
+	scn := scanio.NewBlockScanner(scanio.NewScanner(strings.NewReader("a:\n\n  b")), 4)
+
+	expected := []resultBl{
+		{1, 0, 0, false, 0, 0},
+		{2, 0, 0, false, 0, 0},
+		{3, 2, 1, true, 1, 1},
+	}
+	for _, v := range expected {
+		if !scn.Scan() {
+			t.Errorf("should be %v, is the end", v)
+			return
+		}
+		r := resultBl{scn.NumRead(), scn.Indent(), scn.Depth(), scn.IsBlockBegin(), scn.NumBlockEnds(), scn.ParentNumRead()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestBlockScannerDedentBetweenLevels(t *testing.T) {
+	scn := scanio.NewBlockScanner(scanio.NewScanner(strings.NewReader("a\n    b\n  c\n  d")), 4)
+
+	expected := []resultBl{
+		{1, 0, 0, false, 0, 0},
+		{2, 4, 1, true, 1, 1},
+		{3, 2, 1, true, 0, 1},
+		{4, 2, 1, false, 1, 1},
+	}
+	for _, v := range expected {
+		if !scn.Scan() {
+			t.Errorf("should be %v, is the end", v)
+			return
+		}
+		r := resultBl{scn.NumRead(), scn.Indent(), scn.Depth(), scn.IsBlockBegin(), scn.NumBlockEnds(), scn.ParentNumRead()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package scanio
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrNoCheckpoint is returned by a Checkpointer wrapping a Scanner that cannot tell its position.
+var ErrNoCheckpoint = errors.New("scanner does not support checkpoints")
+
+// Checkpoint is a position of the next unread token in the input. It can be serialized to JSON.
+type Checkpoint struct {
+	Offset  int64 `json:"offset"`  // byte offset of the next unread token
+	NumRead int   `json:"numRead"` // number of tokens read before the next unread one
+}
+
+// Checkpointer is implemented by Scanners which can tell their position in the input:
+// the reader Scanner created by NewScanner or NewScannerAt, and the RuleScanner, OnlyMatchScanner,
+// OnlyNotMatchScanner and AheadScanner wrapping such a Scanner.
+type Checkpointer interface {
+	Checkpoint() (Checkpoint, error)
+}
+
+// NewScannerAt creates a new Scanner resuming the scanning at a checkpoint, with NumRead continuing from it.
+// The checkpoint should come from a Scanner with the same split function.
+// An error of seeking the input is returned by the Scanner's Err.
+func NewScannerAt(r io.ReadSeeker, cp Checkpoint) Scanner {
+	sc := NewScanner(r).(*readerScanner)
+	sc.offset, sc.num = cp.Offset, cp.NumRead
+	_, sc.err = r.Seek(cp.Offset, io.SeekStart)
+	return Scanner(sc)
+}
+
+// checkpointOf returns the checkpoint of a Scanner, or ErrNoCheckpoint if the Scanner is not a Checkpointer.
+func checkpointOf(sc Scanner) (Checkpoint, error) {
+	if c, ok := sc.(Checkpointer); ok {
+		return c.Checkpoint()
+	}
+	return Checkpoint{}, ErrNoCheckpoint
+}
+
+func (sc *readerScanner) Checkpoint() (Checkpoint, error) {
+	return Checkpoint{Offset: sc.offset, NumRead: sc.num}, nil
+}
+
+func (sc *ruleScanner) Checkpoint() (Checkpoint, error) {
+	return checkpointOf(sc.Scanner)
+}
+
+func (sc *onlyMatchScanner) Checkpoint() (Checkpoint, error) {
+	return checkpointOf(sc.Scanner)
+}
+
+func (sc *onlyNotMatchScanner) Checkpoint() (Checkpoint, error) {
+	return checkpointOf(sc.Scanner)
+}
+
+// Checkpoint returns the position after the current token, the one-token read-ahead is not counted.
+func (sc *aheadScanner) Checkpoint() (Checkpoint, error) {
+	if !sc.started {
+		return checkpointOf(sc.Scanner)
+	}
+	return sc.nextInfo.cp, sc.nextInfo.cpErr
+}
@@ -0,0 +1,95 @@
+package scanio_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+const checkpointInput = "one\n# two\n\nfour\r\n# five\nsix"
+
+func TestCheckpointResume(t *testing.T) {
+	all := []string{"one", "# two", "", "four", "# five", "six"}
+	for stop := 0; stop <= len(all); stop++ {
+		scn := scanio.NewScanner(strings.NewReader(checkpointInput))
+		for i := 0; i < stop; i++ {
+			scn.Scan()
+		}
+		cp, err := scn.(scanio.Checkpointer).Checkpoint()
+		if err != nil || cp.NumRead != stop {
+			t.Errorf("%d: should be %d, is %v, %v", stop, stop, cp, err)
+		}
+
+		resumed := scanio.NewScannerAt(strings.NewReader(checkpointInput), cp)
+		for i := stop; i < len(all); i++ {
+			if !resumed.Scan() || resumed.Text() != all[i] || resumed.NumRead() != i+1 {
+				t.Errorf("%d: should be %d %q, is %d %q", stop, i+1, all[i], resumed.NumRead(), resumed.Text())
+			}
+		}
+		if resumed.Scan() || resumed.Err() != nil {
+			t.Errorf("%d: should be the end, is %q, %v", stop, resumed.Text(), resumed.Err())
+		}
+	}
+}
+
+func TestCheckpointJSON(t *testing.T) {
+	b, err := json.Marshal(scanio.Checkpoint{Offset: 42, NumRead: 7})
+	if err != nil || string(b) != `{"offset":42,"numRead":7}` {
+		t.Errorf("should be %s, is %s, %v", `{"offset":42,"numRead":7}`, b, err)
+	}
+	var cp scanio.Checkpoint
+	if err := json.Unmarshal(b, &cp); err != nil || cp.Offset != 42 || cp.NumRead != 7 {
+		t.Errorf("should be %v, is %v, %v", scanio.Checkpoint{Offset: 42, NumRead: 7}, cp, err)
+	}
+}
+
+func TestCheckpointWords(t *testing.T) {
+	scn := scanio.NewScanner(strings.NewReader("  a bb\n ccc  d "))
+	scn.Split(bufio.ScanWords)
+	scn.Scan()
+	scn.Scan()
+	cp, _ := scn.(scanio.Checkpointer).Checkpoint()
+
+	resumed := scanio.NewScannerAt(strings.NewReader("  a bb\n ccc  d "), cp)
+	resumed.Split(bufio.ScanWords)
+	if !resumed.Scan() || resumed.Text() != "ccc" || resumed.NumRead() != 3 {
+		t.Errorf("should be %d %q, is %d %q", 3, "ccc", resumed.NumRead(), resumed.Text())
+	}
+}
+
+func TestCheckpointAheadScanner(t *testing.T) {
+	scn := scanio.NewAheadScanner(scanio.NewFilterScanner(scanio.NewScanner(strings.NewReader(checkpointInput)), isComment))
+	if cp, err := scn.(scanio.Checkpointer).Checkpoint(); err != nil || cp != (scanio.Checkpoint{}) {
+		t.Errorf("should be %v, is %v, %v", scanio.Checkpoint{}, cp, err)
+	}
+	scn.Scan() // "# two", the ahead token is "# five"
+	cp, err := scn.(scanio.Checkpointer).Checkpoint()
+	if err != nil || cp.NumRead != 2 {
+		t.Errorf("should be %d, is %v, %v", 2, cp, err)
+	}
+
+	resumed := scanio.NewAheadScanner(scanio.NewFilterScanner(scanio.NewScannerAt(strings.NewReader(checkpointInput), cp), isComment))
+	expected := []resultL{
+		{true, 5, true, "# five", true},
+		{false, 6, false, "", true},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text, isLast := resumed.Scan(), resumed.NumRead(), resumed.IsMatch(), resumed.Text(), resumed.IsLast()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text || isLast != v.isLast {
+			t.Errorf("should be %v, is %v", v, resultL{res, num, isMatch, text, isLast})
+		}
+	}
+}
+
+func TestCheckpointUnsupported(t *testing.T) {
+	scn := scanio.NewAheadScanner(scanio.NewReverseScanner(strings.NewReader("a\nb")))
+	scn.Scan()
+	if _, err := scn.(scanio.Checkpointer).Checkpoint(); !errors.Is(err, scanio.ErrNoCheckpoint) {
+		t.Errorf("should be %v, is %v", scanio.ErrNoCheckpoint, err)
+	}
+}
@@ -0,0 +1,141 @@
+package scanio
+
+import "bytes"
+
+// ContinuationPolicy decides which physical lines are joined into one logical line.
+type ContinuationPolicy interface {
+	// Continues tells whether the next physical line continues the logical line, the prev line is the last physical line of it.
+	Continues(prev, next []byte) bool
+	// Join appends the next physical line to the logical line.
+	Join(logical, next []byte) []byte
+}
+
+type trailingBackslash struct{}
+
+func (trailingBackslash) Continues(prev, next []byte) bool {
+	return bytes.HasSuffix(prev, []byte(`\`))
+}
+
+func (trailingBackslash) Join(logical, next []byte) []byte {
+	return append(bytes.TrimSuffix(logical, []byte(`\`)), next...)
+}
+
+type leadingWhitespace struct{}
+
+func (leadingWhitespace) Continues(prev, next []byte) bool {
+	return len(next) > 0 && (next[0] == ' ' || next[0] == '\t')
+}
+
+func (leadingWhitespace) Join(logical, next []byte) []byte {
+	return append(logical, next...)
+}
+
+var (
+	// TrailingBackslash joins a line ending with a backslash with the next one, the backslash is removed.
+	// As in shell scripts or Makefiles.
+	TrailingBackslash ContinuationPolicy = trailingBackslash{}
+	// LeadingWhitespace joins a line beginning with a space or a tab with the previous one.
+	// As in RFC 822 headers or INI files.
+	LeadingWhitespace ContinuationPolicy = leadingWhitespace{}
+)
+
+// ContinuationScanner joins physical lines into logical lines.
+type ContinuationScanner interface {
+	Scanner
+	FirstNumRead() int // NumRead of the first physical line of the current logical line
+	LineCount() int    // number of physical lines of the current logical line
+}
+
+type continuationScanner struct {
+	Scanner
+	policy     ContinuationPolicy
+	logical    []byte
+	prev       []byte // last physical line of the logical line
+	first      int
+	last       int
+	count      int
+	pending    []byte // first physical line of the next logical line
+	pendingNum int
+	hasPending bool
+	done       bool
+}
+
+// NewContinuationScanner creates a new ContinuationScanner, lines are joined according to the policy.
+// NumRead is the NumRead of the last physical line of the current logical line.
+func NewContinuationScanner(sc Scanner, policy ContinuationPolicy) ContinuationScanner {
+	return ContinuationScanner(&continuationScanner{
+		Scanner: sc,
+		policy:  policy,
+	})
+}
+
+func (sc *continuationScanner) next() bool {
+	if sc.done || !sc.Scanner.Scan() {
+		sc.done = true
+		return false
+	}
+	return true
+}
+
+func (sc *continuationScanner) Scan() bool {
+	sc.logical, sc.count = sc.logical[:0], 0
+	if sc.hasPending {
+		sc.logical = append(sc.logical, sc.pending...)
+		sc.first, sc.last = sc.pendingNum, sc.pendingNum
+		sc.hasPending = false
+	} else {
+		if !sc.next() {
+			return false
+		}
+		sc.logical = append(sc.logical, sc.Scanner.Bytes()...)
+		sc.first, sc.last = sc.Scanner.NumRead(), sc.Scanner.NumRead()
+	}
+	sc.count = 1
+	sc.prev = append(sc.prev[:0], sc.logical...)
+	for sc.next() {
+		line := sc.Scanner.Bytes()
+		if !sc.policy.Continues(sc.prev, line) {
+			sc.pending = append(sc.pending[:0], line...)
+			sc.pendingNum, sc.hasPending = sc.Scanner.NumRead(), true
+			return true
+		}
+		sc.logical = sc.policy.Join(sc.logical, line)
+		sc.prev = append(sc.prev[:0], line...)
+		sc.last = sc.Scanner.NumRead()
+		sc.count++
+	}
+	return true
+}
+
+func (sc *continuationScanner) Bytes() []byte {
+	if sc.count == 0 {
+		return nil
+	}
+	return sc.logical
+}
+
+func (sc *continuationScanner) Text() string {
+	return string(sc.Bytes())
+}
+
+func (sc *continuationScanner) IsMatch() bool {
+	return sc.count > 0
+}
+
+func (sc *continuationScanner) NumRead() int {
+	if sc.count == 0 {
+		return sc.Scanner.NumRead()
+	}
+	return sc.last
+}
+
+func (sc *continuationScanner) FirstNumRead() int {
+	if sc.count == 0 {
+		return 0
+	}
+	return sc.first
+}
+
+func (sc *continuationScanner) LineCount() int {
+	return sc.count
+}
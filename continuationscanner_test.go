@@ -0,0 +1,94 @@
+package scanio_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+type resultC struct {
+	canParse  bool
+	first     int
+	num       int
+	lineCount int
+	text      string
+}
+
+func TestContinuationScannerBackslash(t *testing.T) {
+	const input = "all: a \\\n\tb \\\n\tc\nclean:\nend \\"
+	scn := scanio.NewContinuationScanner(scanio.NewScanner(strings.NewReader(input)), scanio.TrailingBackslash)
+
+	expected := []resultC{
+		{true, 1, 3, 3, "all: a \tb \tc"},
+		{true, 4, 4, 1, "clean:"},
+		{true, 5, 5, 1, "end \\"},
+		{false, 0, 5, 0, ""},
+		{false, 0, 5, 0, ""},
+	}
+	for _, v := range expected {
+		res := scn.Scan()
+		r := resultC{res, scn.FirstNumRead(), scn.NumRead(), scn.LineCount(), scn.Text()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestContinuationScannerWhitespace(t *testing.T) {
+	const input = " orphan\nSubject: a\n  long\n\tsubject\nFrom: b\n\nbody"
+	scn := scanio.NewContinuationScanner(scanio.NewScanner(strings.NewReader(input)), scanio.LeadingWhitespace)
+
+	expected := []resultC{
+		{true, 1, 1, 1, " orphan"},
+		{true, 2, 4, 3, "Subject: a  long\tsubject"},
+		{true, 5, 5, 1, "From: b"},
+		{true, 6, 6, 1, ""},
+		{true, 7, 7, 1, "body"},
+		{false, 0, 7, 0, ""},
+	}
+	for _, v := range expected {
+		res := scn.Scan()
+		r := resultC{res, scn.FirstNumRead(), scn.NumRead(), scn.LineCount(), scn.Text()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+// commaContinuation joins lines ending with a comma, keeps the line break.
+type commaContinuation struct{}
+
+func (commaContinuation) Continues(prev, next []byte) bool {
+	return bytes.HasSuffix(prev, []byte(","))
+}
+
+func (commaContinuation) Join(logical, next []byte) []byte {
+	return append(append(logical, '\n'), next...)
+}
+
+func TestContinuationScannerCustom(t *testing.T) {
+	scn := scanio.NewContinuationScanner(scanio.NewScanner(strings.NewReader("a,\nb,\nc\nd")), commaContinuation{})
+
+	expected := []resultC{
+		{true, 1, 3, 3, "a,\nb,\nc"},
+		{true, 4, 4, 1, "d"},
+		{false, 0, 4, 0, ""},
+	}
+	for _, v := range expected {
+		res := scn.Scan()
+		r := resultC{res, scn.FirstNumRead(), scn.NumRead(), scn.LineCount(), scn.Text()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestContinuationScannerEmpty(t *testing.T) {
+	scn := scanio.NewContinuationScanner(scanio.NewScanner(strings.NewReader("")), scanio.TrailingBackslash)
+
+	if res := scn.Scan(); res || scn.NumRead() != 0 || scn.Text() != "" {
+		t.Errorf("should be %v, is %v", resultC{}, resultC{res, scn.FirstNumRead(), scn.NumRead(), scn.LineCount(), scn.Text()})
+	}
+}
@@ -0,0 +1,36 @@
+package scanio_test
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func ExampleNewAggregateScanner() {
+	// Let's aggregate positive integers.
+	// Tokens are parsed once, the rule gets the parsed value.
+
+	r := strings.NewReader("123  -456 5 678 173")
+	sc := scanio.NewIntScanner(scanio.NewScanner(r))
+	// read whole words
+	sc.Split(bufio.ScanWords)
+
+	isPositive := func(v int64) (bool, error) {
+		return v > 0, nil
+	}
+
+	// chain the next scanners
+	asc := scanio.NewAggregateScanner(scanio.NewTypedRuleScanner(sc, isPositive))
+
+	for asc.Scan() {
+		fmt.Printf("%v:%d,", asc.NumRead(), asc.Value())
+	}
+	st := asc.Stats()
+	fmt.Printf("\ncount=%d sum=%v min=%v max=%v mean=%v median=%v", st.Count, st.Sum, st.Min, st.Max, st.Mean(), st.Percentile(50))
+
+	// Output:
+	// 1:123,2:-456,3:5,4:678,5:173,
+	// count=4 sum=979 min=5 max=678 mean=244.75 median=148
+}
@@ -0,0 +1,119 @@
+package scanio
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// FieldSeparator splits a token into fields.
+type FieldSeparator func(token []byte) [][]byte
+
+var (
+	// WhitespaceSeparator splits a token around runs of white space, as awk does by default.
+	WhitespaceSeparator FieldSeparator = bytes.Fields
+)
+
+// ByteSeparator returns a FieldSeparator splitting a token around each sep byte, as awk -F does.
+// An empty token has no fields.
+func ByteSeparator(sep byte) FieldSeparator {
+	return func(token []byte) [][]byte {
+		if len(token) == 0 {
+			return nil
+		}
+		return bytes.Split(token, []byte{sep})
+	}
+}
+
+// RegexpSeparator returns a FieldSeparator splitting a token around each match of the re.
+// An empty token has no fields.
+func RegexpSeparator(re *regexp.Regexp) FieldSeparator {
+	return func(token []byte) [][]byte {
+		if len(token) == 0 {
+			return nil
+		}
+		var fields [][]byte
+		start := 0
+		for _, loc := range re.FindAllIndex(token, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			fields = append(fields, token[start:loc[0]])
+			start = loc[1]
+		}
+		return append(fields, token[start:])
+	}
+}
+
+// field returns the i-th field of the fields, counted from 1. 0 stands for the whole token.
+// A field out of range is empty.
+func field(token []byte, fields [][]byte, i int) []byte {
+	switch {
+	case i == 0:
+		return token
+	case i < 0 || i > len(fields):
+		return []byte{}
+	}
+	return fields[i-1]
+}
+
+// FieldMatch returns a MatchRule which applies the rule to the i-th field of a token, counted from 1.
+// Field 0 is the whole token, a field out of range is empty.
+func (sep FieldSeparator) FieldMatch(i int, rule MatchRule) MatchRule {
+	return func(token []byte) (bool, error) {
+		return rule(field(token, sep(token), i))
+	}
+}
+
+// FieldMatch returns a MatchRule which applies the rule to the i-th white space separated field of a token.
+// Like the awk '$i ~ /re/' pattern.
+func FieldMatch(i int, rule MatchRule) MatchRule {
+	return WhitespaceSeparator.FieldMatch(i, rule)
+}
+
+//--------------------------------------------------------------------------------
+
+// FieldScanner splits each token into fields.
+type FieldScanner interface {
+	Scanner
+	Field(i int) []byte // i-th field of the current token, counted from 1. 0 stands for the whole token
+	NF() int            // number of fields of the current token
+}
+
+type fieldScanner struct {
+	Scanner
+	sep    FieldSeparator
+	fields [][]byte
+	split  bool
+}
+
+// NewFieldScanner creates a new FieldScanner. If sep is nil, the WhitespaceSeparator is used.
+// Tokens are split on demand.
+func NewFieldScanner(sc Scanner, sep FieldSeparator) FieldScanner {
+	if sep == nil {
+		sep = WhitespaceSeparator
+	}
+	return FieldScanner(&fieldScanner{
+		Scanner: sc,
+		sep:     sep,
+	})
+}
+
+func (sc *fieldScanner) Scan() bool {
+	sc.fields, sc.split = nil, false
+	return sc.Scanner.Scan()
+}
+
+func (sc *fieldScanner) splitFields() [][]byte {
+	if !sc.split {
+		sc.fields, sc.split = sc.sep(sc.Scanner.Bytes()), true
+	}
+	return sc.fields
+}
+
+func (sc *fieldScanner) Field(i int) []byte {
+	return field(sc.Scanner.Bytes(), sc.splitFields(), i)
+}
+
+func (sc *fieldScanner) NF() int {
+	return len(sc.splitFields())
+}
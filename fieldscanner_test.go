@@ -0,0 +1,89 @@
+package scanio_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func TestSeparators(t *testing.T) {
+	tests := []struct {
+		name  string
+		sep   scanio.FieldSeparator
+		input string
+		want  string
+	}{
+		{"whitespace", scanio.WhitespaceSeparator, "  a \t b  c ", "[a b c]"},
+		{"whitespace empty", scanio.WhitespaceSeparator, "", "[]"},
+		{"byte", scanio.ByteSeparator(':'), "root:x::0", "[root x  0]"},
+		{"byte empty", scanio.ByteSeparator(':'), "", "[]"},
+		{"regexp", scanio.RegexpSeparator(regexp.MustCompile(`\s*,\s*`)), "a , b,c", "[a b c]"},
+		{"regexp no match", scanio.RegexpSeparator(regexp.MustCompile(`,`)), "abc", "[abc]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf("%s", tt.sep([]byte(tt.input))); got != tt.want {
+			t.Errorf("%s: should be %v, is %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestFieldScanner(t *testing.T) {
+	scn := scanio.NewFieldScanner(scanio.NewScanner(strings.NewReader("GET /index 200\n\nPOST /login 500 slow")), nil)
+
+	expected := []struct {
+		nf             int
+		f0, f1, f3, f9 string
+	}{
+		{3, "GET /index 200", "GET", "200", ""},
+		{0, "", "", "", ""},
+		{4, "POST /login 500 slow", "POST", "500", ""},
+	}
+	for _, v := range expected {
+		if !scn.Scan() {
+			t.Errorf("should be %v, is the end", v)
+			return
+		}
+		if scn.NF() != v.nf || string(scn.Field(0)) != v.f0 || string(scn.Field(1)) != v.f1 ||
+			string(scn.Field(3)) != v.f3 || string(scn.Field(9)) != v.f9 {
+			t.Errorf("should be %v, is %d %q %q %q %q", v, scn.NF(), scn.Field(0), scn.Field(1), scn.Field(3), scn.Field(9))
+		}
+	}
+}
+
+func TestFieldMatch(t *testing.T) {
+	const input = "POST /login 500\nGET /admin 503\nGET /index 200"
+	is5xx := func(b []byte) (bool, error) {
+		return bytes.HasPrefix(b, []byte("5")), nil
+	}
+	// awk '$3 ~ /^5/'
+	scn := scanio.NewFilterScanner(scanio.NewScanner(strings.NewReader(input)), scanio.FieldMatch(3, is5xx))
+
+	expected := []result{
+		{true, 1, true, "POST /login 500"},
+		{true, 2, true, "GET /admin 503"},
+		{false, 3, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestFieldMatchSeparator(t *testing.T) {
+	isRoot := func(b []byte) (bool, error) {
+		return string(b) == "0", nil
+	}
+	rule := scanio.ByteSeparator(':').FieldMatch(3, isRoot)
+	for input, want := range map[string]bool{"root:x:0:0": true, "bin:x:1:1": false, "short": false} {
+		if got, err := rule([]byte(input)); err != nil || got != want {
+			t.Errorf("%q: should be %v, is %v, %v", input, want, got, err)
+		}
+	}
+}
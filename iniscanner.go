@@ -0,0 +1,139 @@
+package scanio
+
+import "bytes"
+
+// INILineKind is a kind of an INI file line.
+type INILineKind int
+
+const (
+	INIBlank    INILineKind = iota // empty or white space only
+	INIComment                     // begins with a '#' or ';'
+	INISection                     // [section]
+	INIKeyValue                    // key=value or key: value
+	INIInvalid                     // none of the above
+)
+
+// INIScanner reads INI or properties files, line by line.
+// IsMatch is true for key-value lines.
+type INIScanner interface {
+	ContinuationScanner
+	Kind() INILineKind // kind of the current line
+	Section() string   // name of the section the current line is in, "" before the first section header
+	Key() string       // key of a key-value line, "" for other lines
+	Value() string     // value of a key-value line, "" for other lines
+}
+
+type noContinuation struct{}
+
+func (noContinuation) Continues(prev, next []byte) bool {
+	return false
+}
+
+func (noContinuation) Join(logical, next []byte) []byte {
+	return append(logical, next...)
+}
+
+// iniContinuation lets only key-value lines be continued by the policy.
+type iniContinuation struct {
+	policy      ContinuationPolicy
+	continued   bool // the prev line is a continuation line
+	keyValue    bool // the logical line is a key-value line
+	firstIndent int
+}
+
+func (c *iniContinuation) Continues(prev, next []byte) bool {
+	if !c.continued {
+		// prev is the first physical line of a logical line
+		kind, _, _, _ := parseINILine(prev)
+		c.keyValue = kind == INIKeyValue
+		c.firstIndent = indentLen(prev)
+	}
+	c.continued = c.keyValue && c.policy.Continues(prev, next)
+	if c.continued && c.policy == LeadingWhitespace {
+		// indented keys below an indented key are not continuation lines
+		c.continued = indentLen(next) > c.firstIndent
+	}
+	return c.continued
+}
+
+func (c *iniContinuation) Join(logical, next []byte) []byte {
+	return c.policy.Join(logical, next)
+}
+
+// indentLen returns the length of the leading white space of the line.
+func indentLen(line []byte) int {
+	return len(line) - len(bytes.TrimLeft(line, " \t"))
+}
+
+type iniScanner struct {
+	ContinuationScanner
+	kind       INILineKind
+	section    string
+	key, value string
+}
+
+// NewINIScanner creates a new INIScanner. Continuation lines are joined according to the policy,
+// which can be nil for no continuation lines. Only key-value lines can have continuation lines.
+// With the LeadingWhitespace policy, a continuation line must be indented deeper than its key line,
+// as in Python's configparser, so indented keys of git-config style files stay keys.
+// Keys, values and section names are trimmed of white space.
+func NewINIScanner(sc Scanner, policy ContinuationPolicy) INIScanner {
+	if policy == nil {
+		policy = noContinuation{}
+	}
+	return INIScanner(&iniScanner{
+		ContinuationScanner: NewContinuationScanner(sc, &iniContinuation{policy: policy}),
+	})
+}
+
+func (sc *iniScanner) Scan() bool {
+	sc.kind, sc.key, sc.value = INIBlank, "", ""
+	if !sc.ContinuationScanner.Scan() {
+		return false
+	}
+	var section string
+	sc.kind, section, sc.key, sc.value = parseINILine(sc.ContinuationScanner.Bytes())
+	if sc.kind == INISection {
+		sc.section = section
+	}
+	return true
+}
+
+// parseINILine returns the kind of a line, with a section name of a section header,
+// or a key and a value of a key-value line.
+func parseINILine(line []byte) (kind INILineKind, section, key, value string) {
+	line = bytes.TrimSpace(line)
+	switch {
+	case len(line) == 0:
+		return INIBlank, "", "", ""
+	case line[0] == '#' || line[0] == ';':
+		return INIComment, "", "", ""
+	case line[0] == '[' && line[len(line)-1] == ']':
+		return INISection, string(bytes.TrimSpace(line[1 : len(line)-1])), "", ""
+	}
+	i := bytes.IndexAny(line, "=:")
+	if i <= 0 {
+		return INIInvalid, "", "", ""
+	}
+	return INIKeyValue, "", string(bytes.TrimSpace(line[:i])), string(bytes.TrimSpace(line[i+1:]))
+}
+
+func (sc *iniScanner) IsMatch() bool {
+	return sc.kind == INIKeyValue
+}
+
+func (sc *iniScanner) Kind() INILineKind {
+	return sc.kind
+}
+
+func (sc *iniScanner) Section() string {
+	return sc.section
+}
+
+func (sc *iniScanner) Key() string {
+	return sc.key
+}
+
+func (sc *iniScanner) Value() string {
+	return sc.value
+}
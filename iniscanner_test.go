@@ -0,0 +1,112 @@
+package scanio_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+type resultI struct {
+	num     int
+	kind    scanio.INILineKind
+	section string
+	key     string
+	value   string
+}
+
+func TestINIScanner(t *testing.T) {
+	f, err := os.Open("assets/simpleConfig.ini")
+	defer f.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	scn := scanio.NewINIScanner(scanio.NewScanner(f), scanio.LeadingWhitespace)
+
+	expected := []resultI{
+		{1, scanio.INIComment, "", "", ""},
+		{2, scanio.INIKeyValue, "", "name", "scanio"},
+		{3, scanio.INIBlank, "", "", ""},
+		{4, scanio.INISection, "server", "", ""},
+		{5, scanio.INIKeyValue, "server", "host", "localhost"},
+		{6, scanio.INIComment, "server", "", ""},
+		{7, scanio.INIKeyValue, "server", "port", "8080"},
+		{9, scanio.INIKeyValue, "server", "description", "a long  description"},
+		{10, scanio.INIBlank, "server", "", ""},
+		{11, scanio.INISection, "client", "", ""},
+		{12, scanio.INIKeyValue, "client", "retries", "3"},
+		{13, scanio.INIInvalid, "client", "", ""},
+	}
+	for _, v := range expected {
+		if !scn.Scan() {
+			t.Errorf("should be %v, is the end", v)
+			return
+		}
+		r := resultI{scn.NumRead(), scn.Kind(), scn.Section(), scn.Key(), scn.Value()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+		if scn.IsMatch() != (v.kind == scanio.INIKeyValue) {
+			t.Errorf("at %d: IsMatch should be %v", v.num, v.kind == scanio.INIKeyValue)
+		}
+	}
+	if scn.Scan() || scn.IsMatch() {
+		t.Errorf("should be the end")
+	}
+}
+
+func TestINIScannerIndentedKeys(t *testing.T) {
+	const input = "[core]\n\tbare = false\n\tname = x\n\t\tcontinued\n# c\n\tkey = y\n\n\tz = 1"
+	scn := scanio.NewINIScanner(scanio.NewScanner(strings.NewReader(input)), scanio.LeadingWhitespace)
+
+	expected := []resultI{
+		{1, scanio.INISection, "core", "", ""},
+		{2, scanio.INIKeyValue, "core", "bare", "false"},
+		{4, scanio.INIKeyValue, "core", "name", "x\t\tcontinued"},
+		{5, scanio.INIComment, "core", "", ""},
+		{6, scanio.INIKeyValue, "core", "key", "y"},
+		{7, scanio.INIBlank, "core", "", ""},
+		{8, scanio.INIKeyValue, "core", "z", "1"},
+	}
+	for _, v := range expected {
+		if !scn.Scan() {
+			t.Errorf("should be %v, is the end", v)
+			return
+		}
+		r := resultI{scn.NumRead(), scn.Kind(), scn.Section(), scn.Key(), scn.Value()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+	if scn.Scan() {
+		t.Errorf("should be the end, is %q", scn.Text())
+	}
+}
+
+func TestINIScannerSimpleFile(t *testing.T) {
+	f, err := os.Open("assets/simpleFile.txt")
+	defer f.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	scn := scanio.NewINIScanner(scanio.NewScanner(f), nil)
+
+	kinds := map[int]scanio.INILineKind{3: scanio.INIBlank, 5: scanio.INIBlank, 6: scanio.INIComment, 10: scanio.INIComment}
+	for scn.Scan() {
+		want, ok := kinds[scn.NumRead()]
+		if !ok {
+			want = scanio.INIInvalid
+		}
+		if scn.Kind() != want {
+			t.Errorf("at %d: should be %v, is %v", scn.NumRead(), want, scn.Kind())
+		}
+	}
+	if scn.NumRead() != 11 {
+		t.Errorf("should be %v, is %v", 11, scn.NumRead())
+	}
+}
@@ -0,0 +1,174 @@
+package scanio
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrMalformedJSON is returned by the JSONLinesScanner for a line which is not a valid JSON.
+var ErrMalformedJSON = errors.New("scanio: malformed JSON")
+
+// MalformedPolicy tells the JSONLinesScanner what to do with malformed lines.
+type MalformedPolicy int
+
+const (
+	// MalformedError stops the scanning, Err returns an error wrapping the ErrMalformedJSON.
+	MalformedError MalformedPolicy = iota
+	// MalformedSkip omits malformed lines.
+	MalformedSkip
+)
+
+// JSONLinesScanner reads JSON Lines (NDJSON), one JSON value per token.
+type JSONLinesScanner interface {
+	Scanner
+	Decode(v any) error // decodes the current token into v, as json.Unmarshal does
+}
+
+type jsonLinesScanner struct {
+	Scanner
+	policy MalformedPolicy
+	err    error
+}
+
+// NewJSONLinesScanner creates a new JSONLinesScanner. Blank lines are omitted.
+// Tokens are only validated while scanning, they are decoded on demand.
+func NewJSONLinesScanner(sc Scanner, policy MalformedPolicy) JSONLinesScanner {
+	return JSONLinesScanner(&jsonLinesScanner{
+		Scanner: sc,
+		policy:  policy,
+	})
+}
+
+func (sc *jsonLinesScanner) Scan() bool {
+	if sc.err != nil {
+		return false
+	}
+	for sc.Scanner.Scan() {
+		b := sc.Scanner.Bytes()
+		if len(bytes.TrimSpace(b)) == 0 {
+			continue
+		}
+		if json.Valid(b) {
+			return true
+		}
+		if sc.policy == MalformedSkip {
+			continue
+		}
+		sc.err = fmt.Errorf("%w at token %d", ErrMalformedJSON, sc.Scanner.NumRead())
+		return false
+	}
+	return false
+}
+
+func (sc *jsonLinesScanner) Decode(v any) error {
+	return json.Unmarshal(sc.Scanner.Bytes(), v)
+}
+
+func (sc *jsonLinesScanner) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
+	return sc.Scanner.Err()
+}
+
+//--------------------------------------------------------------------------------
+
+// jsonLookup returns the raw JSON value at the path. Array elements are addressed by their index.
+func jsonLookup(data []byte, path []string) (json.RawMessage, bool, error) {
+	raw := json.RawMessage(data)
+	for _, key := range path {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			return nil, false, nil
+		}
+		switch raw[0] {
+		case '{':
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &obj); err != nil {
+				return nil, false, err
+			}
+			v, ok := obj[key]
+			if !ok {
+				return nil, false, nil
+			}
+			raw = v
+		case '[':
+			i, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, false, nil
+			}
+			var arr []json.RawMessage
+			if err := json.Unmarshal(raw, &arr); err != nil {
+				return nil, false, err
+			}
+			if i < 0 || i >= len(arr) {
+				return nil, false, nil
+			}
+			raw = arr[i]
+		default:
+			if !json.Valid(raw) {
+				return nil, false, ErrMalformedJSON
+			}
+			return nil, false, nil
+		}
+	}
+	return raw, true, nil
+}
+
+// JSONFieldExists returns a MatchRule which matches a JSON object having the field.
+func JSONFieldExists(field string) MatchRule {
+	return func(token []byte) (bool, error) {
+		_, ok, err := jsonLookup(token, []string{field})
+		return ok, err
+	}
+}
+
+// JSONFieldEquals returns a MatchRule which matches a JSON object having the field equal to the value.
+// The value is compared after a JSON round trip, so JSONFieldEquals("status", 200) matches {"status":200.0}.
+func JSONFieldEquals(field string, value any) MatchRule {
+	var want any
+	b, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(b, &want)
+	}
+	return func(token []byte) (bool, error) {
+		if err != nil {
+			return false, err
+		}
+		raw, ok, lerr := jsonLookup(token, []string{field})
+		if !ok || lerr != nil {
+			return false, lerr
+		}
+		var got any
+		if err := json.Unmarshal(raw, &got); err != nil {
+			return false, err
+		}
+		return reflect.DeepEqual(got, want), nil
+	}
+}
+
+// JSONPathMatch returns a MatchRule which applies the rule to the value at the dot-separated path,
+// e.g. "request.headers.0". Array elements are addressed by their index.
+// The rule gets a string value unquoted, other values as JSON text. A missing value does not match.
+func JSONPathMatch(path string, rule MatchRule) MatchRule {
+	keys := strings.Split(path, ".")
+	return func(token []byte) (bool, error) {
+		raw, ok, err := jsonLookup(token, keys)
+		if !ok || err != nil {
+			return false, err
+		}
+		if len(raw) > 0 && raw[0] == '"' {
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return false, err
+			}
+			return rule([]byte(s))
+		}
+		return rule(raw)
+	}
+}
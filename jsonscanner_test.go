@@ -0,0 +1,119 @@
+package scanio_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+const ndjsonInput = `{"level":"info","msg":"started","status":200}
+
+{"level":"error","msg":"failed","req":{"path":"/a","tags":["x","y"]}}
+not a json
+{"level":"error","msg":"again","status":500.0}
+`
+
+func TestJSONLinesScannerSkip(t *testing.T) {
+	scn := scanio.NewJSONLinesScanner(scanio.NewScanner(strings.NewReader(ndjsonInput)), scanio.MalformedSkip)
+
+	expected := []int{1, 3, 5}
+	for _, v := range expected {
+		if !scn.Scan() || scn.NumRead() != v {
+			t.Errorf("should be %v, is %v", v, scn.NumRead())
+		}
+	}
+	if scn.Scan() || scn.Err() != nil {
+		t.Errorf("should be the end, is %q, %v", scn.Text(), scn.Err())
+	}
+}
+
+func TestJSONLinesScannerError(t *testing.T) {
+	scn := scanio.NewJSONLinesScanner(scanio.NewScanner(strings.NewReader(ndjsonInput)), scanio.MalformedError)
+
+	count := 0
+	for scn.Scan() {
+		count++
+	}
+	if count != 2 || !errors.Is(scn.Err(), scanio.ErrMalformedJSON) {
+		t.Errorf("should be %v, %v, is %v, %v", 2, scanio.ErrMalformedJSON, count, scn.Err())
+	}
+	if scn.Scan() {
+		t.Errorf("should stay at the end")
+	}
+}
+
+func TestJSONLinesScannerDecode(t *testing.T) {
+	scn := scanio.NewJSONLinesScanner(scanio.NewScanner(strings.NewReader(ndjsonInput)), scanio.MalformedSkip)
+
+	var entry struct {
+		Level string
+		Msg   string
+	}
+	msgs := []string{}
+	for scn.Scan() {
+		if err := scn.Decode(&entry); err != nil {
+			t.Error(err)
+		}
+		msgs = append(msgs, entry.Level+":"+entry.Msg)
+	}
+	if strings.Join(msgs, ",") != "info:started,error:failed,error:again" {
+		t.Errorf("should be %v, is %v", "info:started,error:failed,error:again", msgs)
+	}
+}
+
+func TestJSONRules(t *testing.T) {
+	line1 := []byte(`{"level":"info","msg":"started","status":200}`)
+	line3 := []byte(`{"level":"error","msg":"failed","req":{"path":"/a","tags":["x","y"]}}`)
+	hasY := func(b []byte) (bool, error) {
+		return bytes.Equal(b, []byte("y")), nil
+	}
+
+	tests := []struct {
+		name  string
+		rule  scanio.MatchRule
+		token []byte
+		want  bool
+	}{
+		{"equals", scanio.JSONFieldEquals("level", "error"), line3, true},
+		{"not equals", scanio.JSONFieldEquals("level", "error"), line1, false},
+		{"equals number", scanio.JSONFieldEquals("status", 200), line1, true},
+		{"equals missing", scanio.JSONFieldEquals("status", 200), line3, false},
+		{"exists", scanio.JSONFieldExists("req"), line3, true},
+		{"not exists", scanio.JSONFieldExists("req"), line1, false},
+		{"path", scanio.JSONPathMatch("req.tags.1", hasY), line3, true},
+		{"path index out of range", scanio.JSONPathMatch("req.tags.2", hasY), line3, false},
+		{"path through scalar", scanio.JSONPathMatch("msg.x", hasY), line3, false},
+		{"path object", scanio.JSONPathMatch("req", func(b []byte) (bool, error) {
+			return bytes.HasPrefix(b, []byte("{")), nil
+		}), line3, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.rule(tt.token)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: should be %v, is %v, %v", tt.name, tt.want, got, err)
+		}
+	}
+
+	if _, err := scanio.JSONFieldExists("a")([]byte(`{"a":`)); err == nil {
+		t.Errorf("should be an error for a malformed token")
+	}
+}
+
+func TestJSONLinesScannerFilter(t *testing.T) {
+	scn := scanio.NewFilterScanner(
+		scanio.NewJSONLinesScanner(scanio.NewScanner(strings.NewReader(ndjsonInput)), scanio.MalformedSkip),
+		scanio.JSONFieldEquals("level", "error"))
+
+	expected := []int{3, 5}
+	for _, v := range expected {
+		if !scn.Scan() || scn.NumRead() != v {
+			t.Errorf("should be %v, is %v", v, scn.NumRead())
+		}
+	}
+	if scn.Scan() {
+		t.Errorf("should be the end, is %q", scn.Text())
+	}
+}
@@ -0,0 +1,302 @@
+package scanio
+
+// limitScanner passes tokens until a limit is reached.
+type limitScanner struct {
+	Scanner
+	limit     int
+	count     int
+	matchOnly bool
+	done      bool
+}
+
+// NewTakeScanner returns a Scanner that outputs at most n tokens.
+func NewTakeScanner(sc Scanner, n int) Scanner {
+	return Scanner(&limitScanner{
+		Scanner: sc,
+		limit:   n,
+	})
+}
+
+// NewTakeMatchScanner returns a Scanner that outputs tokens until the n-th matching token (inclusive).
+// Only the matching tokens are counted.
+// Chained with the OnlyMatchScanner it behaves like "grep -m n".
+func NewTakeMatchScanner(sc Scanner, n int) Scanner {
+	return Scanner(&limitScanner{
+		Scanner:   sc,
+		limit:     n,
+		matchOnly: true,
+	})
+}
+
+func (sc *limitScanner) Scan() bool {
+	if sc.done || sc.count >= sc.limit {
+		sc.done = true
+		return false
+	}
+	if !sc.Scanner.Scan() {
+		sc.done = true
+		return false
+	}
+	if !sc.matchOnly || sc.Scanner.IsMatch() {
+		sc.count++
+	}
+	return true
+}
+
+func (sc *limitScanner) Text() string {
+	if sc.done {
+		return ""
+	}
+	return sc.Scanner.Text()
+}
+
+func (sc *limitScanner) Bytes() []byte {
+	if sc.done {
+		return nil
+	}
+	return sc.Scanner.Bytes()
+}
+
+func (sc *limitScanner) IsMatch() bool {
+	if sc.done {
+		return false
+	}
+	return sc.Scanner.IsMatch()
+}
+
+// skipScanner omits tokens until a limit is reached.
+type skipScanner struct {
+	Scanner
+	limit     int
+	matchOnly bool
+	skipped   bool
+}
+
+// NewSkipScanner returns a Scanner that omits the first n tokens.
+func NewSkipScanner(sc Scanner, n int) Scanner {
+	return Scanner(&skipScanner{
+		Scanner: sc,
+		limit:   n,
+	})
+}
+
+// NewSkipMatchScanner returns a Scanner that omits all tokens up to the n-th matching token (inclusive).
+// Only the matching tokens are counted.
+func NewSkipMatchScanner(sc Scanner, n int) Scanner {
+	return Scanner(&skipScanner{
+		Scanner:   sc,
+		limit:     n,
+		matchOnly: true,
+	})
+}
+
+func (sc *skipScanner) Scan() bool {
+	if !sc.skipped {
+		sc.skipped = true
+		for count := 0; count < sc.limit; {
+			if !sc.Scanner.Scan() {
+				return false
+			}
+			if !sc.matchOnly || sc.Scanner.IsMatch() {
+				count++
+			}
+		}
+	}
+	return sc.Scanner.Scan()
+}
+
+//--------------------------------------------------------------------------------
+
+// whileScanner passes (or omits) tokens as long as its rule matches.
+type whileScanner struct {
+	Scanner
+	rule  MatchRule
+	take  bool
+	state int
+	err   error
+}
+
+const (
+	whileActive = iota
+	whileOver
+	whileDone
+)
+
+// NewTakeWhileScanner returns a Scanner that outputs tokens as long as they match the rule.
+// Stops at the first not-matching token.
+func NewTakeWhileScanner(sc Scanner, rule MatchRule) Scanner {
+	return Scanner(&whileScanner{
+		Scanner: sc,
+		rule:    rule,
+		take:    true,
+	})
+}
+
+// NewDropWhileScanner returns a Scanner that omits tokens as long as they match the rule.
+// Outputs the first not-matching token and all tokens after it.
+func NewDropWhileScanner(sc Scanner, rule MatchRule) Scanner {
+	return Scanner(&whileScanner{
+		Scanner: sc,
+		rule:    rule,
+	})
+}
+
+func (sc *whileScanner) Scan() bool {
+	switch sc.state {
+	case whileDone:
+		return false
+	case whileOver:
+		if sc.take {
+			return false
+		}
+		if !sc.Scanner.Scan() {
+			sc.state = whileDone
+			return false
+		}
+		return true
+	}
+	for sc.Scanner.Scan() {
+		var matched bool
+		matched, sc.err = sc.rule(sc.Scanner.Bytes())
+		if sc.err != nil {
+			sc.state = whileDone
+			return false
+		}
+		if matched {
+			if sc.take {
+				return true
+			}
+			continue
+		}
+		sc.state = whileOver
+		if sc.take {
+			sc.state = whileDone
+			return false
+		}
+		return true
+	}
+	sc.state = whileDone
+	return false
+}
+
+func (sc *whileScanner) Text() string {
+	if sc.state == whileDone {
+		return ""
+	}
+	return sc.Scanner.Text()
+}
+
+func (sc *whileScanner) Bytes() []byte {
+	if sc.state == whileDone {
+		return nil
+	}
+	return sc.Scanner.Bytes()
+}
+
+func (sc *whileScanner) IsMatch() bool {
+	if sc.state == whileDone {
+		return false
+	}
+	return sc.Scanner.IsMatch()
+}
+
+func (sc *whileScanner) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
+	return sc.Scanner.Err()
+}
+
+//--------------------------------------------------------------------------------
+
+// replayScanner reads the whole input on the first Scan, then replays the collected tokens.
+type replayScanner struct {
+	Scanner
+	collect func(sc Scanner) []*info
+	infos   []*info
+	cur     *info
+	started bool
+}
+
+func (sc *replayScanner) Scan() bool {
+	if !sc.started {
+		sc.started = true
+		sc.infos = sc.collect(sc.Scanner)
+		sc.cur = &info{NumRead: sc.Scanner.NumRead()}
+	}
+	if len(sc.infos) == 0 {
+		sc.cur = &info{NumRead: sc.cur.NumRead, Err: sc.Scanner.Err()}
+		return false
+	}
+	sc.cur, sc.infos = sc.infos[0], sc.infos[1:]
+	return true
+}
+
+func (sc *replayScanner) Text() string {
+	return sc.cur.Text
+}
+
+func (sc *replayScanner) Bytes() []byte {
+	return sc.cur.Bytes
+}
+
+func (sc *replayScanner) Err() error {
+	return sc.cur.Err
+}
+
+func (sc *replayScanner) IsMatch() bool {
+	return sc.cur.IsMatch
+}
+
+func (sc *replayScanner) NumRead() int {
+	return sc.cur.NumRead
+}
+
+// NewTailScanner returns an AheadScanner that outputs the last n tokens of the input.
+// The input is read to its end on the first Scan.
+func NewTailScanner(sc Scanner, n int) AheadScanner {
+	return NewAheadScanner(&replayScanner{
+		Scanner: sc,
+		collect: tailCollector(n, false),
+	})
+}
+
+// NewTailMatchScanner returns an AheadScanner that outputs the last n matching tokens of the input.
+func NewTailMatchScanner(sc Scanner, n int) AheadScanner {
+	return NewAheadScanner(&replayScanner{
+		Scanner: sc,
+		collect: tailCollector(n, true),
+	})
+}
+
+// tailCollector keeps the last n (matching) tokens in a ring.
+func tailCollector(n int, matchOnly bool) func(sc Scanner) []*info {
+	return func(sc Scanner) []*info {
+		if n <= 0 {
+			for sc.Scan() {
+			}
+			return nil
+		}
+		ring := make([]*info, n)
+		for i := range ring {
+			ring[i] = &info{}
+		}
+		head, num := 0, 0
+		for sc.Scan() {
+			if matchOnly && !sc.IsMatch() {
+				continue
+			}
+			ring[(head+num)%n].update(sc, true)
+			if num < n {
+				num++
+			} else {
+				head = (head + 1) % n
+			}
+		}
+		res := make([]*info, num)
+		for i := range res {
+			res[i] = ring[(head+i)%n]
+		}
+		return res
+	}
+}
@@ -0,0 +1,251 @@
+package scanio_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func isComment(b []byte) (bool, error) {
+	return bytes.HasPrefix(b, []byte("#")), nil
+}
+
+func TestTakeScanner(t *testing.T) {
+	f := strings.NewReader("one two three four")
+	scn := scanio.NewTakeScanner(scanio.NewScanner(f), 2)
+	scn.Split(bufio.ScanWords)
+
+	expected := []result{
+		{true, 1, true, "one"},
+		{true, 2, true, "two"},
+		{false, 2, false, ""},
+		{false, 2, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestTakeScannerShortInput(t *testing.T) {
+	f := strings.NewReader("one")
+	scn := scanio.NewTakeScanner(scanio.NewScanner(f), 5)
+
+	expected := []result{
+		{true, 1, true, "one"},
+		{false, 1, false, ""},
+		{false, 1, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestTakeMatchScanner(t *testing.T) {
+	f, err := os.Open("assets/simpleFile.txt")
+	defer f.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// grep -m 1 '^#'
+	scn := scanio.NewOnlyMatchScanner(
+		scanio.NewTakeMatchScanner(
+			scanio.NewRuleScanner(scanio.NewScanner(f), isComment), 1))
+
+	expected := []result{
+		{true, 6, true, "# bash-like comment"},
+		{false, 6, false, ""},
+		{false, 6, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestSkipScanner(t *testing.T) {
+	f := strings.NewReader("one two three")
+	scn := scanio.NewSkipScanner(scanio.NewScanner(f), 2)
+	scn.Split(bufio.ScanWords)
+
+	expected := []result{
+		{true, 3, true, "three"},
+		{false, 3, false, ""},
+		{false, 3, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestSkipMatchScanner(t *testing.T) {
+	f, err := os.Open("assets/simpleFile.txt")
+	defer f.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	scn := scanio.NewSkipMatchScanner(
+		scanio.NewRuleScanner(scanio.NewScanner(f), isComment), 1)
+
+	expected := []result{
+		{true, 7, false, "line with two trailing spaces  "},
+		{true, 8, false, " line with one leading space"},
+		{true, 9, false, " line with one leading and one trailing space "},
+		{true, 10, true, "# bash-like comment 2 "},
+		{true, 11, false, "last line"},
+		{false, 11, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestTakeWhileScanner(t *testing.T) {
+	f := strings.NewReader("# a\n# b\nc\n# d")
+	scn := scanio.NewTakeWhileScanner(scanio.NewScanner(f), isComment)
+
+	expected := []result{
+		{true, 1, true, "# a"},
+		{true, 2, true, "# b"},
+		{false, 3, false, ""},
+		{false, 3, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestDropWhileScanner(t *testing.T) {
+	f := strings.NewReader("# a\n# b\nc\n# d")
+	scn := scanio.NewDropWhileScanner(scanio.NewScanner(f), isComment)
+
+	expected := []result{
+		{true, 3, true, "c"},
+		{true, 4, true, "# d"},
+		{false, 4, false, ""},
+		{false, 4, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestTakeWhileScannerError(t *testing.T) {
+	errRule := errors.New("rule error")
+	f := strings.NewReader("# a\nb")
+	scn := scanio.NewTakeWhileScanner(scanio.NewScanner(f), func(b []byte) (bool, error) {
+		if b[0] != '#' {
+			return false, errRule
+		}
+		return true, nil
+	})
+
+	for scn.Scan() {
+	}
+	if scn.Err() != errRule {
+		t.Errorf("should be %v, is %v", errRule, scn.Err())
+	}
+}
+
+func TestTailScanner(t *testing.T) {
+	f, err := os.Open("assets/simpleFile.txt")
+	defer f.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	scn := scanio.NewTailScanner(scanio.NewScanner(f), 3)
+
+	expected := []resultL{
+		{true, 9, true, " line with one leading and one trailing space ", false},
+		{true, 10, true, "# bash-like comment 2 ", false},
+		{true, 11, true, "last line", true},
+		{false, 11, false, "", true},
+		{false, 11, false, "", true},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text, isLast := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text(), scn.IsLast()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text || isLast != v.isLast {
+			t.Errorf("should be %v, is %v", v, resultL{res, num, isMatch, text, isLast})
+		}
+	}
+}
+
+func TestTailMatchScanner(t *testing.T) {
+	f, err := os.Open("assets/simpleFile.txt")
+	defer f.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	scn := scanio.NewTailMatchScanner(
+		scanio.NewRuleScanner(scanio.NewScanner(f), isComment), 5)
+
+	expected := []resultL{
+		{true, 6, true, "# bash-like comment", false},
+		{true, 10, true, "# bash-like comment 2 ", true},
+		{false, 10, false, "", true},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text, isLast := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text(), scn.IsLast()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text || isLast != v.isLast {
+			t.Errorf("should be %v, is %v", v, resultL{res, num, isMatch, text, isLast})
+		}
+	}
+}
+
+func TestTailScannerEmpty(t *testing.T) {
+	f := strings.NewReader("")
+	scn := scanio.NewTailScanner(scanio.NewScanner(f), 3)
+
+	expected := []resultL{
+		{false, 0, false, "", true},
+		{false, 0, false, "", true},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text, isLast := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text(), scn.IsLast()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text || isLast != v.isLast {
+			t.Errorf("should be %v, is %v", v, resultL{res, num, isMatch, text, isLast})
+		}
+	}
+}
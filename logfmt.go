@@ -0,0 +1,235 @@
+package scanio
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KV is a key-value pair of a logfmt token.
+type KV struct {
+	Key, Value string
+}
+
+// ParseLogfmt parses a logfmt token, such as:
+//
+//	level=info msg="user logged in" duration=12ms debug
+//
+// Values can be double-quoted, with Go escape sequences. A key without a value has an empty value.
+// Parsing is lenient: an unterminated quoted value takes the rest of the token.
+func ParseLogfmt(token []byte) []KV {
+	var pairs []KV
+	s := string(token)
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return pairs
+		}
+		end := strings.IndexAny(s, "= \t")
+		if end < 0 {
+			return append(pairs, KV{Key: s})
+		}
+		key := s[:end]
+		s = s[end:]
+		if s[0] != '=' {
+			pairs = append(pairs, KV{Key: key})
+			continue
+		}
+		s = s[1:]
+		var value string
+		value, s = parseLogfmtValue(s)
+		if key != "" {
+			pairs = append(pairs, KV{Key: key, Value: value})
+		}
+	}
+}
+
+// parseLogfmtValue returns the value at the beginning of s, and the rest of s.
+func parseLogfmtValue(s string) (value, rest string) {
+	if !strings.HasPrefix(s, `"`) {
+		end := strings.IndexAny(s, " \t")
+		if end < 0 {
+			return s, ""
+		}
+		return s[:end], s[end:]
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			if v, err := strconv.Unquote(s[:i+1]); err == nil {
+				return v, s[i+1:]
+			}
+			return s[1:i], s[i+1:]
+		}
+	}
+	return s[1:], ""
+}
+
+// LogfmtScanner reads logfmt tokens.
+type LogfmtScanner interface {
+	Scanner
+	Pairs() []KV               // key-value pairs of the current token, in their order
+	Fields() map[string]string // key-value pairs of the current token, the last value wins for a repeated key
+}
+
+type logfmtScanner struct {
+	Scanner
+	pairs  []KV
+	parsed bool
+}
+
+// NewLogfmtScanner creates a new LogfmtScanner. Tokens are parsed on demand.
+func NewLogfmtScanner(sc Scanner) LogfmtScanner {
+	return LogfmtScanner(&logfmtScanner{
+		Scanner: sc,
+	})
+}
+
+func (sc *logfmtScanner) Scan() bool {
+	sc.pairs, sc.parsed = nil, false
+	return sc.Scanner.Scan()
+}
+
+func (sc *logfmtScanner) Pairs() []KV {
+	if !sc.parsed {
+		sc.pairs, sc.parsed = ParseLogfmt(sc.Scanner.Bytes()), true
+	}
+	return sc.pairs
+}
+
+func (sc *logfmtScanner) Fields() map[string]string {
+	fields := make(map[string]string)
+	for _, kv := range sc.Pairs() {
+		fields[kv.Key] = kv.Value
+	}
+	return fields
+}
+
+//--------------------------------------------------------------------------------
+
+// ErrInvalidKVFilter is returned for a malformed key-value filter expression or comparison operator.
+var ErrInvalidKVFilter = errors.New("scanio: invalid key-value filter")
+
+// logfmtValue returns the last value of the key in a logfmt token.
+func logfmtValue(token []byte, key string) (string, bool) {
+	value, found := "", false
+	for _, kv := range ParseLogfmt(token) {
+		if kv.Key == key {
+			value, found = kv.Value, true
+		}
+	}
+	return value, found
+}
+
+// KVEquals returns a MatchRule which matches a logfmt token having the key with the value.
+func KVEquals(key, value string) MatchRule {
+	return func(token []byte) (bool, error) {
+		v, ok := logfmtValue(token, key)
+		return ok && v == value, nil
+	}
+}
+
+// KVMatches returns a MatchRule which matches a logfmt token having the key with a value matching the re.
+func KVMatches(key string, re *regexp.Regexp) MatchRule {
+	return func(token []byte) (bool, error) {
+		v, ok := logfmtValue(token, key)
+		return ok && re.MatchString(v), nil
+	}
+}
+
+// parseNumeric parses a number, or a duration (a number with a unit, such as "500ms") as seconds.
+func parseNumeric(s string) (float64, bool) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d.Seconds(), true
+	}
+	return 0, false
+}
+
+var compareOps = map[string]func(a, b float64) bool{
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+}
+
+// KVNumericCompare returns a MatchRule which compares a numeric value of the key with the value,
+// using the op: "<", "<=", ">", ">=", "==" or "!=".
+// Both values can be numbers or durations, such as "1.5" or "500ms". Durations are compared in seconds.
+// A token without the key, or with a non-numeric value, does not match.
+func KVNumericCompare(key, op, value string) MatchRule {
+	cmp, okOp := compareOps[op]
+	want, okValue := parseNumeric(value)
+	return func(token []byte) (bool, error) {
+		if !okOp || !okValue {
+			return false, fmt.Errorf("%w: %s %s %s", ErrInvalidKVFilter, key, op, value)
+		}
+		v, ok := logfmtValue(token, key)
+		if !ok {
+			return false, nil
+		}
+		got, ok := parseNumeric(v)
+		return ok && cmp(got, want), nil
+	}
+}
+
+var kvTermRe = regexp.MustCompile(`^([^=!<>~]+)(==|!=|<=|>=|=|<|>|~)(.*)$`)
+
+// ParseKVFilter parses a space-separated list of conditions into a MatchRule matching a logfmt token
+// that satisfies all of them. A condition can be:
+//
+//	key=value   KVEquals
+//	key!=value  not KVEquals
+//	key~regexp  KVMatches
+//	key<number  KVNumericCompare, also with <=, >, >= and ==
+//
+// Example: "level=error duration>500ms".
+func ParseKVFilter(expr string) (MatchRule, error) {
+	var rules []MatchRule
+	for _, term := range strings.Fields(expr) {
+		m := kvTermRe.FindStringSubmatch(term)
+		if m == nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidKVFilter, term)
+		}
+		key, op, value := m[1], m[2], m[3]
+		switch op {
+		case "=":
+			rules = append(rules, KVEquals(key, value))
+		case "!=":
+			eq := KVEquals(key, value)
+			rules = append(rules, func(token []byte) (bool, error) {
+				matched, err := eq(token)
+				return !matched, err
+			})
+		case "~":
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %v", ErrInvalidKVFilter, term, err)
+			}
+			rules = append(rules, KVMatches(key, re))
+		default:
+			if _, ok := parseNumeric(value); !ok {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidKVFilter, term)
+			}
+			rules = append(rules, KVNumericCompare(key, op, value))
+		}
+	}
+	return func(token []byte) (bool, error) {
+		for _, rule := range rules {
+			matched, err := rule(token)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+		return true, nil
+	}, nil
+}
@@ -0,0 +1,128 @@
+package scanio_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func TestParseLogfmt(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{``, `[]`},
+		{`a=1 b=two`, `[{a 1} {b two}]`},
+		{`  msg="hello \"world\"" debug  x=`, `[{msg hello "world"} {debug } {x }]`},
+		{`k="a b`, `[{k a b}]`},
+		{`=v k=v2`, `[{k v2}]`},
+		{`path=/a=b`, `[{path /a=b}]`},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(scanio.ParseLogfmt([]byte(tt.input))); got != tt.want {
+			t.Errorf("%q: should be %v, is %v", tt.input, tt.want, got)
+		}
+	}
+}
+
+const logfmtInput = `level=info msg=started duration=12ms
+level=error msg="request failed" duration=1.5s
+level=error msg=timeout duration=250ms
+level=debug msg=noise`
+
+func TestLogfmtScanner(t *testing.T) {
+	scn := scanio.NewLogfmtScanner(scanio.NewScanner(strings.NewReader(logfmtInput)))
+
+	scn.Scan()
+	scn.Scan()
+	if got := fmt.Sprint(scn.Pairs()); got != `[{level error} {msg request failed} {duration 1.5s}]` {
+		t.Errorf("should be pairs of line 2, is %v", got)
+	}
+	if got := scn.Fields()["msg"]; got != "request failed" {
+		t.Errorf("should be %q, is %q", "request failed", got)
+	}
+	scn.Scan()
+	if got := scn.Fields()["msg"]; got != "timeout" {
+		t.Errorf("should be %q, is %q", "timeout", got)
+	}
+}
+
+func TestKVRules(t *testing.T) {
+	token := []byte(`level=error msg="request failed" duration=1.5s count=7`)
+	tests := []struct {
+		name string
+		rule scanio.MatchRule
+		want bool
+	}{
+		{"equals", scanio.KVEquals("level", "error"), true},
+		{"equals other", scanio.KVEquals("level", "info"), false},
+		{"equals missing", scanio.KVEquals("user", ""), false},
+		{"matches", scanio.KVMatches("msg", regexp.MustCompile(`fail`)), true},
+		{"duration >", scanio.KVNumericCompare("duration", ">", "500ms"), true},
+		{"duration <", scanio.KVNumericCompare("duration", "<", "1s"), false},
+		{"number", scanio.KVNumericCompare("count", ">=", "7"), true},
+		{"not numeric", scanio.KVNumericCompare("msg", ">", "1"), false},
+	}
+	for _, tt := range tests {
+		got, err := tt.rule(token)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: should be %v, is %v, %v", tt.name, tt.want, got, err)
+		}
+	}
+
+	if _, err := scanio.KVNumericCompare("count", "=>", "7")(token); !errors.Is(err, scanio.ErrInvalidKVFilter) {
+		t.Errorf("should be %v, is %v", scanio.ErrInvalidKVFilter, err)
+	}
+}
+
+func TestParseKVFilter(t *testing.T) {
+	rule, err := scanio.ParseKVFilter("level=error duration>500ms")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	scn := scanio.NewFilterScanner(scanio.NewScanner(strings.NewReader(logfmtInput)), rule)
+
+	expected := []result{
+		{true, 2, true, `level=error msg="request failed" duration=1.5s`},
+		{false, 4, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestParseKVFilterOps(t *testing.T) {
+	token := []byte(`level=error msg=timeout`)
+	tests := map[string]bool{
+		"level!=info":           true,
+		"level!=error":          false,
+		"msg~^time":             true,
+		"level=error msg~^fail": false,
+		"":                      true,
+	}
+	for expr, want := range tests {
+		rule, err := scanio.ParseKVFilter(expr)
+		if err != nil {
+			t.Errorf("%q: %v", expr, err)
+			continue
+		}
+		if got, err := rule(token); err != nil || got != want {
+			t.Errorf("%q: should be %v, is %v, %v", expr, want, got, err)
+		}
+	}
+
+	for _, expr := range []string{"level", "=x", "d>abc", "msg~("} {
+		if _, err := scanio.ParseKVFilter(expr); !errors.Is(err, scanio.ErrInvalidKVFilter) {
+			t.Errorf("%q: should be %v, is %v", expr, scanio.ErrInvalidKVFilter, err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package scanio
+
+import "errors"
+
+// ErrInvalidMark is returned by Reset if there is no mark, or the mark has been invalidated
+// by reading more tokens than the limit after it.
+var ErrInvalidMark = errors.New("invalid mark")
+
+// MarkableScanner can go back to a marked token and scan the tokens after it again,
+// with their original NumRead and IsMatch.
+type MarkableScanner interface {
+	Scanner
+	Mark()        // marks the current token
+	Reset() error // goes back to the marked token, the next Scan returns the token after it
+}
+
+type markableScanner struct {
+	Scanner
+	limit  int
+	marked bool
+	buf    []*info // the marked token and tokens read after it
+	pos    int     // index of the current token in the buf
+	cur    *info
+}
+
+// NewMarkableScanner creates a new MarkableScanner. The mark is valid until more than limit tokens are read after it,
+// then it is invalidated and its buffered tokens are dropped. The mark stays valid after the Reset.
+func NewMarkableScanner(sc Scanner, limit int) MarkableScanner {
+	return MarkableScanner(&markableScanner{
+		Scanner: sc,
+		limit:   limit,
+	})
+}
+
+func (sc *markableScanner) Mark() {
+	if sc.marked {
+		// keep the tokens already read after the current one
+		sc.buf = sc.buf[sc.pos:]
+	} else {
+		cur := sc.cur
+		if cur == nil {
+			cur = &info{}
+		}
+		sc.buf = []*info{cur}
+	}
+	sc.pos = 0
+	sc.marked = true
+}
+
+func (sc *markableScanner) Reset() error {
+	if !sc.marked {
+		return ErrInvalidMark
+	}
+	sc.pos = 0
+	sc.cur = sc.buf[0]
+	return nil
+}
+
+func (sc *markableScanner) Scan() bool {
+	if sc.marked && sc.pos+1 < len(sc.buf) {
+		// replay
+		sc.pos++
+		sc.cur = sc.buf[sc.pos]
+		return sc.cur.ScanRes
+	}
+	res := sc.Scanner.Scan()
+	sc.cur = &info{}
+	sc.cur.update(sc.Scanner, res)
+	if sc.marked {
+		if len(sc.buf) > sc.limit {
+			sc.marked, sc.buf, sc.pos = false, nil, 0
+		} else {
+			sc.buf = append(sc.buf, sc.cur)
+			sc.pos++
+		}
+	}
+	return res
+}
+
+func (sc *markableScanner) Text() string {
+	if sc.cur == nil {
+		return sc.Scanner.Text()
+	}
+	return sc.cur.Text
+}
+
+func (sc *markableScanner) Bytes() []byte {
+	if sc.cur == nil {
+		return sc.Scanner.Bytes()
+	}
+	return sc.cur.Bytes
+}
+
+func (sc *markableScanner) Err() error {
+	if sc.cur == nil {
+		return sc.Scanner.Err()
+	}
+	return sc.cur.Err
+}
+
+func (sc *markableScanner) IsMatch() bool {
+	if sc.cur == nil {
+		return sc.Scanner.IsMatch()
+	}
+	return sc.cur.IsMatch
+}
+
+func (sc *markableScanner) NumRead() int {
+	if sc.cur == nil {
+		return sc.Scanner.NumRead()
+	}
+	return sc.cur.NumRead
+}
@@ -0,0 +1,96 @@
+package scanio_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func scanResult(scn scanio.Scanner) result {
+	res := scn.Scan()
+	return result{res, scn.NumRead(), scn.IsMatch(), scn.Text()}
+}
+
+func TestMarkableScanner(t *testing.T) {
+	scn := scanio.NewMarkableScanner(
+		scanio.NewRuleScanner(scanio.NewScanner(strings.NewReader("a\n# b\n# c\nd")), isComment), 3)
+
+	if err := scn.Reset(); err != scanio.ErrInvalidMark {
+		t.Errorf("should be %v, is %v", scanio.ErrInvalidMark, err)
+	}
+	scn.Scan()
+	scn.Mark()
+	scanResult(scn)
+	scanResult(scn)
+	if err := scn.Reset(); err != nil {
+		t.Errorf("should be %v, is %v", nil, err)
+	}
+	if scn.Text() != "a" || scn.NumRead() != 1 {
+		t.Errorf("should be back at %q, is %d %q", "a", scn.NumRead(), scn.Text())
+	}
+
+	expected := []result{
+		{true, 2, true, "# b"},
+		{true, 3, true, "# c"},
+		{true, 4, false, "d"},
+		{false, 4, false, ""},
+	}
+	for _, v := range expected {
+		if got := scanResult(scn); got != v {
+			t.Errorf("should be %v, is %v", v, got)
+		}
+	}
+	// the mark is still valid after the Reset, 4 tokens read after it
+	if err := scn.Reset(); err != scanio.ErrInvalidMark {
+		t.Errorf("should be %v, is %v", scanio.ErrInvalidMark, err)
+	}
+}
+
+func TestMarkableScannerRemark(t *testing.T) {
+	scn := scanio.NewMarkableScanner(scanio.NewScanner(strings.NewReader("1\n2\n3\n4\n5")), 2)
+
+	scn.Mark()
+	scanResult(scn)
+	scanResult(scn)
+	scn.Reset()
+	scanResult(scn) // "1", replayed
+	scn.Mark()      // "2" is already buffered
+	scanResult(scn)
+	scanResult(scn)
+	if err := scn.Reset(); err != nil {
+		t.Errorf("should be %v, is %v", nil, err)
+	}
+	expected := []result{
+		{true, 2, true, "2"},
+		{true, 3, true, "3"},
+		{true, 4, true, "4"},
+		{true, 5, true, "5"},
+	}
+	for _, v := range expected {
+		if got := scanResult(scn); got != v {
+			t.Errorf("should be %v, is %v", v, got)
+		}
+	}
+}
+
+func TestMarkableScannerBeforeStart(t *testing.T) {
+	scn := scanio.NewMarkableScanner(scanio.NewScanner(strings.NewReader("x")), 5)
+
+	scn.Mark()
+	scanResult(scn)
+	scanResult(scn)
+	scn.Reset()
+	if scn.NumRead() != 0 || scn.Text() != "" {
+		t.Errorf("should be at the start, is %d %q", scn.NumRead(), scn.Text())
+	}
+	expected := []result{
+		{true, 1, true, "x"},
+		{false, 1, false, ""},
+	}
+	for _, v := range expected {
+		if got := scanResult(scn); got != v {
+			t.Errorf("should be %v, is %v", v, got)
+		}
+	}
+}
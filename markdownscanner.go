@@ -0,0 +1,146 @@
+package scanio
+
+import (
+	"bytes"
+	"strings"
+)
+
+// MarkdownRegion is a part of a Markdown document a line belongs to.
+type MarkdownRegion int
+
+const (
+	MarkdownProse       MarkdownRegion = iota // ordinary text
+	MarkdownFence                             // fenced code block, ``` or ~~~
+	MarkdownFrontMatter                       // YAML front matter between "---" lines at the beginning
+)
+
+// MarkdownScanner reads a Markdown document line by line, tracking fenced code blocks and front matter.
+// Its rules tell the state of the scanner rather than inspect the token, so they are to be used
+// with this very scanner, for example:
+//
+//	md := scanio.NewMarkdownScanner(sc)
+//	code := scanio.NewFilterScanner(md, md.InFence("go"))
+type MarkdownScanner interface {
+	Scanner
+	Region() MarkdownRegion // region of the current line
+	IsDelimiter() bool      // true if the current line opens or closes a fence or the front matter
+	Language() string       // language of the current fence, the first word of its info string
+
+	InFence(lang string) MatchRule // matches content lines of fences of the language, of any fence if lang is ""
+	InFrontMatter() MatchRule      // matches content lines of the front matter
+	InProse() MatchRule            // matches lines outside fences and front matter
+}
+
+type markdownScanner struct {
+	Scanner
+	region      MarkdownRegion
+	delimiter   bool
+	fenceChar   byte
+	fenceLen    int
+	lang        string
+	afterFirst  bool
+	closeRegion bool // the region ends after the current line
+}
+
+// NewMarkdownScanner creates a new MarkdownScanner.
+func NewMarkdownScanner(sc Scanner) MarkdownScanner {
+	return MarkdownScanner(&markdownScanner{
+		Scanner: sc,
+	})
+}
+
+// parseFence returns the fence char, length and info string of a fence line, or length 0.
+func parseFence(line []byte) (char byte, length int, info string) {
+	indent := 0
+	for indent < len(line) && line[indent] == ' ' {
+		indent++
+	}
+	if indent > 3 || indent == len(line) {
+		return 0, 0, ""
+	}
+	char = line[indent]
+	if char != '`' && char != '~' {
+		return 0, 0, ""
+	}
+	length = 0
+	for indent+length < len(line) && line[indent+length] == char {
+		length++
+	}
+	if length < 3 {
+		return 0, 0, ""
+	}
+	rest := bytes.TrimSpace(line[indent+length:])
+	if char == '`' && bytes.IndexByte(rest, '`') >= 0 {
+		return 0, 0, ""
+	}
+	return char, length, string(rest)
+}
+
+func (sc *markdownScanner) Scan() bool {
+	if sc.closeRegion {
+		sc.region, sc.lang, sc.closeRegion = MarkdownProse, "", false
+	}
+	sc.delimiter = false
+	if !sc.Scanner.Scan() {
+		sc.region, sc.lang = MarkdownProse, ""
+		return false
+	}
+	line := sc.Scanner.Bytes()
+	first := !sc.afterFirst
+	sc.afterFirst = true
+
+	switch sc.region {
+	case MarkdownProse:
+		if first && string(bytes.TrimRight(line, " \t\r")) == "---" {
+			sc.region, sc.delimiter = MarkdownFrontMatter, true
+			break
+		}
+		if char, length, info := parseFence(line); length > 0 {
+			sc.region, sc.delimiter = MarkdownFence, true
+			sc.fenceChar, sc.fenceLen = char, length
+			sc.lang = ""
+			if fields := strings.Fields(info); len(fields) > 0 {
+				sc.lang = fields[0]
+			}
+		}
+	case MarkdownFence:
+		if char, length, info := parseFence(line); char == sc.fenceChar && length >= sc.fenceLen && info == "" {
+			sc.delimiter, sc.closeRegion = true, true
+		}
+	case MarkdownFrontMatter:
+		if s := string(bytes.TrimRight(line, " \t\r")); s == "---" || s == "..." {
+			sc.delimiter, sc.closeRegion = true, true
+		}
+	}
+	return true
+}
+
+func (sc *markdownScanner) Region() MarkdownRegion {
+	return sc.region
+}
+
+func (sc *markdownScanner) IsDelimiter() bool {
+	return sc.delimiter
+}
+
+func (sc *markdownScanner) Language() string {
+	return sc.lang
+}
+
+func (sc *markdownScanner) InFence(lang string) MatchRule {
+	return func(token []byte) (bool, error) {
+		return sc.region == MarkdownFence && !sc.delimiter && (lang == "" || sc.lang == lang), nil
+	}
+}
+
+func (sc *markdownScanner) InFrontMatter() MatchRule {
+	return func(token []byte) (bool, error) {
+		return sc.region == MarkdownFrontMatter && !sc.delimiter, nil
+	}
+}
+
+func (sc *markdownScanner) InProse() MatchRule {
+	return func(token []byte) (bool, error) {
+		return sc.region == MarkdownProse, nil
+	}
+}
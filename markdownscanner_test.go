@@ -0,0 +1,110 @@
+package scanio_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+const markdownInput = "---\n" +
+	"title: Doc\n" +
+	"---\n" +
+	"Intro\n" +
+	"```go\n" +
+	"a := 1\n" +
+	"b := 2\n" +
+	"```\n" +
+	"Text\n" +
+	"~~~~ sh -x\n" +
+	"ls\n" +
+	"~~~\n" +
+	"~~~~\n" +
+	"```go\n" +
+	"c := 3\n" +
+	"````"
+
+func TestMarkdownScanner(t *testing.T) {
+	scn := scanio.NewMarkdownScanner(scanio.NewScanner(strings.NewReader(markdownInput)))
+
+	expected := []struct {
+		region    scanio.MarkdownRegion
+		delimiter bool
+		lang      string
+	}{
+		{scanio.MarkdownFrontMatter, true, ""},
+		{scanio.MarkdownFrontMatter, false, ""},
+		{scanio.MarkdownFrontMatter, true, ""},
+		{scanio.MarkdownProse, false, ""},
+		{scanio.MarkdownFence, true, "go"},
+		{scanio.MarkdownFence, false, "go"},
+		{scanio.MarkdownFence, false, "go"},
+		{scanio.MarkdownFence, true, "go"},
+		{scanio.MarkdownProse, false, ""},
+		{scanio.MarkdownFence, true, "sh"},
+		{scanio.MarkdownFence, false, "sh"},
+		{scanio.MarkdownFence, false, "sh"}, // too short to close the fence
+		{scanio.MarkdownFence, true, "sh"},
+		{scanio.MarkdownFence, true, "go"},
+		{scanio.MarkdownFence, false, "go"},
+		{scanio.MarkdownFence, true, "go"},
+	}
+	for i, v := range expected {
+		if !scn.Scan() {
+			t.Errorf("at %d: should be %v, is the end", i+1, v)
+			return
+		}
+		if scn.Region() != v.region || scn.IsDelimiter() != v.delimiter || scn.Language() != v.lang {
+			t.Errorf("at %d: should be %v, is {%v %v %v}", i+1, v, scn.Region(), scn.IsDelimiter(), scn.Language())
+		}
+	}
+	if scn.Scan() || scn.Region() != scanio.MarkdownProse {
+		t.Errorf("should be the end")
+	}
+}
+
+func TestMarkdownScannerNoFrontMatter(t *testing.T) {
+	scn := scanio.NewMarkdownScanner(scanio.NewScanner(strings.NewReader("Intro\n---\nText")))
+
+	for scn.Scan() {
+		if scn.Region() != scanio.MarkdownProse {
+			t.Errorf("at %d: should be %v, is %v", scn.NumRead(), scanio.MarkdownProse, scn.Region())
+		}
+	}
+}
+
+func TestMarkdownScannerExtractCode(t *testing.T) {
+	md := scanio.NewMarkdownScanner(scanio.NewScanner(strings.NewReader(markdownInput)))
+	scn := scanio.NewAheadScanner(scanio.NewFilterScanner(md, md.InFence("go")))
+
+	var out []string
+	for scn.Scan() {
+		if scn.IsConsecutiveBegin() {
+			out = append(out, fmt.Sprintf("[%d]", scn.NumRead()))
+		}
+		out = append(out, scn.Text())
+	}
+	if got, want := strings.Join(out, "|"), "[6]|a := 1|b := 2|[15]|c := 3"; got != want {
+		t.Errorf("should be %v, is %v", want, got)
+	}
+}
+
+func TestMarkdownScannerFrontMatter(t *testing.T) {
+	md := scanio.NewMarkdownScanner(scanio.NewScanner(strings.NewReader(markdownInput)))
+	scn := scanio.NewFilterScanner(md, md.InFrontMatter())
+
+	expected := []result{
+		{true, 2, true, "title: Doc"},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+	if scn.Scan() {
+		t.Errorf("should be the end, is %q", scn.Text())
+	}
+}
@@ -0,0 +1,144 @@
+package scanio
+
+import (
+	"bufio"
+	"container/heap"
+)
+
+// MergeScanner merges tokens of sorted Scanners, and tells where the current token comes from.
+type MergeScanner interface {
+	Scanner
+	Source() int        // index of the Scanner the current token comes from, -1 if there is no current token
+	SourceNumRead() int // NumRead of the Scanner the current token comes from
+}
+
+// mergeHeap holds indexes of Scanners, ordered by their current tokens.
+type mergeHeap struct {
+	scs  []Scanner
+	idx  []int
+	less func(a, b []byte) bool
+}
+
+func (h *mergeHeap) Len() int { return len(h.idx) }
+
+func (h *mergeHeap) Less(i, j int) bool {
+	a, b := h.scs[h.idx[i]].Bytes(), h.scs[h.idx[j]].Bytes()
+	if h.less(a, b) {
+		return true
+	}
+	if h.less(b, a) {
+		return false
+	}
+	// equal tokens come in the order of Scanners
+	return h.idx[i] < h.idx[j]
+}
+
+func (h *mergeHeap) Swap(i, j int) { h.idx[i], h.idx[j] = h.idx[j], h.idx[i] }
+
+func (h *mergeHeap) Push(x any) { h.idx = append(h.idx, x.(int)) }
+
+func (h *mergeHeap) Pop() any {
+	x := h.idx[len(h.idx)-1]
+	h.idx = h.idx[:len(h.idx)-1]
+	return x
+}
+
+type mergeScanner struct {
+	heap    mergeHeap
+	cur     int
+	num     int
+	started bool
+	err     error
+}
+
+// NewMergeScanner creates a new MergeScanner, doing a k-way merge of Scanners with tokens sorted by the less function.
+// Equal tokens come in the order of Scanners. NumRead counts the merged tokens.
+// Scanning stops at the first error of any Scanner.
+func NewMergeScanner(less func(a, b []byte) bool, scs ...Scanner) MergeScanner {
+	return MergeScanner(&mergeScanner{
+		heap: mergeHeap{
+			scs:  scs,
+			less: less,
+		},
+		cur: -1,
+	})
+}
+
+// advance scans the next token of a Scanner, and puts the Scanner to the heap if there is one.
+func (sc *mergeScanner) advance(i int) {
+	s := sc.heap.scs[i]
+	if s.Scan() {
+		heap.Push(&sc.heap, i)
+		return
+	}
+	if err := s.Err(); err != nil && sc.err == nil {
+		sc.err = err
+	}
+}
+
+func (sc *mergeScanner) Scan() bool {
+	if !sc.started {
+		sc.started = true
+		for i := range sc.heap.scs {
+			sc.advance(i)
+		}
+	} else if sc.cur >= 0 {
+		sc.advance(sc.cur)
+	}
+	if sc.err != nil || sc.heap.Len() == 0 {
+		sc.cur = -1
+		return false
+	}
+	sc.cur = heap.Pop(&sc.heap).(int)
+	sc.num++
+	return true
+}
+
+func (sc *mergeScanner) Buffer(buf []byte, max int) {
+	for _, s := range sc.heap.scs {
+		s.Buffer(make([]byte, len(buf), cap(buf)), max)
+	}
+}
+
+func (sc *mergeScanner) Split(split bufio.SplitFunc) {
+	for _, s := range sc.heap.scs {
+		s.Split(split)
+	}
+}
+
+func (sc *mergeScanner) Text() string {
+	if sc.cur < 0 {
+		return ""
+	}
+	return sc.heap.scs[sc.cur].Text()
+}
+
+func (sc *mergeScanner) Bytes() []byte {
+	if sc.cur < 0 {
+		return nil
+	}
+	return sc.heap.scs[sc.cur].Bytes()
+}
+
+func (sc *mergeScanner) Err() error {
+	return sc.err
+}
+
+func (sc *mergeScanner) IsMatch() bool {
+	return sc.cur >= 0 && sc.heap.scs[sc.cur].IsMatch()
+}
+
+func (sc *mergeScanner) NumRead() int {
+	return sc.num
+}
+
+func (sc *mergeScanner) Source() int {
+	return sc.cur
+}
+
+func (sc *mergeScanner) SourceNumRead() int {
+	if sc.cur < 0 {
+		return 0
+	}
+	return sc.heap.scs[sc.cur].NumRead()
+}
@@ -0,0 +1,78 @@
+package scanio_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+type resultM struct {
+	result
+	source, sourceNum int
+}
+
+func TestMergeScanner(t *testing.T) {
+	scn := scanio.NewMergeScanner(func(a, b []byte) bool { return bytes.Compare(a, b) < 0 },
+		scanio.NewScanner(strings.NewReader("10:00 a1\n10:05 a2\n10:09 a3")),
+		scanio.NewScanner(strings.NewReader("")),
+		scanio.NewScanner(strings.NewReader("09:59 c1\n10:05 c2\n11:00 c3")),
+	)
+
+	expected := []resultM{
+		{result{true, 1, true, "09:59 c1"}, 2, 1},
+		{result{true, 2, true, "10:00 a1"}, 0, 1},
+		{result{true, 3, true, "10:05 a2"}, 0, 2},
+		{result{true, 4, true, "10:05 c2"}, 2, 2},
+		{result{true, 5, true, "10:09 a3"}, 0, 3},
+		{result{true, 6, true, "11:00 c3"}, 2, 3},
+		{result{false, 6, false, ""}, -1, 0},
+		{result{false, 6, false, ""}, -1, 0},
+	}
+	for _, v := range expected {
+		got := resultM{scanResult(scn), scn.Source(), scn.SourceNumRead()}
+		if got != v {
+			t.Errorf("should be %v, is %v", v, got)
+		}
+	}
+}
+
+func TestMergeScannerKeys(t *testing.T) {
+	// compare by the first field only, equal keys keep the order of Scanners
+	key := func(b []byte) []byte { return bytes.Fields(b)[0] }
+	scn := scanio.NewMergeScanner(func(a, b []byte) bool { return bytes.Compare(key(a), key(b)) < 0 },
+		scanio.NewScanner(strings.NewReader("1 z\n2 z")),
+		scanio.NewScanner(strings.NewReader("1 a\n2 a")),
+	)
+
+	var got []string
+	for scn.Scan() {
+		got = append(got, scn.Text())
+	}
+	if strings.Join(got, ",") != "1 z,1 a,2 z,2 a" {
+		t.Errorf("should be %v, is %v", "1 z,1 a,2 z,2 a", got)
+	}
+}
+
+func TestMergeScannerError(t *testing.T) {
+	errRule := errors.New("rule error")
+	scn := scanio.NewMergeScanner(func(a, b []byte) bool { return bytes.Compare(a, b) < 0 },
+		scanio.NewScanner(strings.NewReader("a\nc")),
+		scanio.NewRuleScanner(scanio.NewScanner(strings.NewReader("b\nbad")), func(b []byte) (bool, error) {
+			if string(b) == "bad" {
+				return false, errRule
+			}
+			return true, nil
+		}),
+	)
+
+	var got []string
+	for scn.Scan() {
+		got = append(got, scn.Text())
+	}
+	if strings.Join(got, ",") != "a,b" || scn.Err() != errRule {
+		t.Errorf("should be %v, %v, is %v, %v", "a,b", errRule, got, scn.Err())
+	}
+}
@@ -0,0 +1,106 @@
+package scanio
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// NewIntScanner creates a TypedScanner parsing integer tokens, with a base prefix allowed (as strconv.ParseInt with base 0).
+func NewIntScanner(sc Scanner) TypedScanner[int64] {
+	return NewTypedScanner(sc, func(token []byte) (int64, error) {
+		return strconv.ParseInt(string(token), 0, 64)
+	})
+}
+
+// NewFloatScanner creates a TypedScanner parsing floating-point tokens.
+func NewFloatScanner(sc Scanner) TypedScanner[float64] {
+	return NewTypedScanner(sc, func(token []byte) (float64, error) {
+		return strconv.ParseFloat(string(token), 64)
+	})
+}
+
+// Number is a constraint for the AggregateScanner values.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Stats holds aggregated values.
+type Stats struct {
+	Count         int
+	Sum, Min, Max float64
+	values        []float64
+	sorted        bool
+}
+
+// Mean returns the arithmetic mean of the values, NaN if there are none.
+func (s *Stats) Mean() float64 {
+	if s.Count == 0 {
+		return math.NaN()
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// Percentile returns the p-th percentile (0 <= p <= 100) of the values, linearly interpolated between the closest ranks.
+// Returns NaN if there are no values.
+func (s *Stats) Percentile(p float64) float64 {
+	if s.Count == 0 {
+		return math.NaN()
+	}
+	if !s.sorted {
+		sort.Float64s(s.values)
+		s.sorted = true
+	}
+	p = math.Max(0, math.Min(100, p))
+	rank := p / 100 * float64(len(s.values)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return s.values[lo] + (s.values[hi]-s.values[lo])*(rank-float64(lo))
+}
+
+func (s *Stats) add(v float64) {
+	if s.Count == 0 || v < s.Min {
+		s.Min = v
+	}
+	if s.Count == 0 || v > s.Max {
+		s.Max = v
+	}
+	s.Count++
+	s.Sum += v
+	s.values = append(s.values, v)
+	s.sorted = false
+}
+
+// AggregateScanner aggregates values of matching tokens while scanning.
+type AggregateScanner[T Number] interface {
+	TypedScanner[T]
+	Stats() *Stats // stats of the matching tokens scanned so far, complete when Scan returns false
+}
+
+type aggregateScanner[T Number] struct {
+	TypedScanner[T]
+	stats Stats
+}
+
+// NewAggregateScanner creates a new AggregateScanner. All tokens are output, only the matching ones are aggregated.
+func NewAggregateScanner[T Number](sc TypedScanner[T]) AggregateScanner[T] {
+	return AggregateScanner[T](&aggregateScanner[T]{
+		TypedScanner: sc,
+	})
+}
+
+func (sc *aggregateScanner[T]) Scan() bool {
+	if !sc.TypedScanner.Scan() {
+		return false
+	}
+	if sc.TypedScanner.IsMatch() {
+		sc.stats.add(float64(sc.TypedScanner.Value()))
+	}
+	return true
+}
+
+func (sc *aggregateScanner[T]) Stats() *Stats {
+	return &sc.stats
+}
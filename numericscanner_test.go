@@ -0,0 +1,70 @@
+package scanio_test
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func TestIntScanner(t *testing.T) {
+	scn := scanio.NewIntScanner(scanio.NewScanner(strings.NewReader("12 -3 0x10 abc")))
+	scn.Split(bufio.ScanWords)
+
+	var got []int64
+	for scn.Scan() {
+		got = append(got, scn.Value())
+	}
+	if fmt.Sprint(got) != "[12 -3 16]" || scn.Err() == nil {
+		t.Errorf("should be %v and an error, is %v, %v", "[12 -3 16]", got, scn.Err())
+	}
+}
+
+func TestFloatScanner(t *testing.T) {
+	scn := scanio.NewFloatScanner(scanio.NewScanner(strings.NewReader("1.5\n-2\n1e3")))
+
+	var got []float64
+	for scn.Scan() {
+		got = append(got, scn.Value())
+	}
+	if fmt.Sprint(got) != "[1.5 -2 1000]" || scn.Err() != nil {
+		t.Errorf("should be %v, is %v, %v", "[1.5 -2 1000]", got, scn.Err())
+	}
+}
+
+func TestAggregateScanner(t *testing.T) {
+	scn := scanio.NewAggregateScanner(scanio.NewTypedRuleScanner(
+		scanio.NewIntScanner(scanio.NewScanner(strings.NewReader("10 -5 20 40 -1 30"))),
+		func(v int64) (bool, error) {
+			return v > 0, nil
+		}))
+	scn.Split(bufio.ScanWords)
+
+	count := 0
+	for scn.Scan() {
+		count++
+	}
+	st := scn.Stats()
+	if count != 6 || st.Count != 4 || st.Sum != 100 || st.Min != 10 || st.Max != 40 || st.Mean() != 25 {
+		t.Errorf("should be 6 4 100 10 40 25, is %d %d %v %v %v %v", count, st.Count, st.Sum, st.Min, st.Max, st.Mean())
+	}
+	for p, want := range map[float64]float64{0: 10, 50: 25, 100: 40, 25: 17.5, 150: 40} {
+		if got := st.Percentile(p); got != want {
+			t.Errorf("p%v: should be %v, is %v", p, want, got)
+		}
+	}
+}
+
+func TestAggregateScannerEmpty(t *testing.T) {
+	scn := scanio.NewAggregateScanner(scanio.NewFloatScanner(scanio.NewScanner(strings.NewReader(""))))
+
+	for scn.Scan() {
+	}
+	st := scn.Stats()
+	if st.Count != 0 || !math.IsNaN(st.Mean()) || !math.IsNaN(st.Percentile(50)) {
+		t.Errorf("should be empty stats, is %v %v %v", st.Count, st.Mean(), st.Percentile(50))
+	}
+}
@@ -0,0 +1,123 @@
+package scanio
+
+// RecordScanner joins multi-line records, such as log entries with stack traces, into single tokens.
+// A record begins with a head line and contains all following non-head lines.
+type RecordScanner interface {
+	Scanner
+	HeadNumRead() int // NumRead of the head line of the current record
+	LineCount() int   // number of lines in the current record
+}
+
+type recordScanner struct {
+	Scanner
+	isHead     MatchRule
+	record     []byte
+	head, last int
+	count      int
+	match      bool
+	pending    []byte // head line of the next record
+	pendingNum int
+	hasPending bool
+	done       bool
+	err        error
+}
+
+// NewRecordScanner creates a new RecordScanner. Head lines are recognized by the isHead rule.
+// Lines of a record are joined with "\n". Lines before the first head line form a record of their own,
+// this one is the only record with IsMatch false.
+// NumRead is the NumRead of the last line of the current record.
+func NewRecordScanner(sc Scanner, isHead MatchRule) RecordScanner {
+	return RecordScanner(&recordScanner{
+		Scanner: sc,
+		isHead:  isHead,
+	})
+}
+
+// next scans the next line and tells if it is a head line.
+func (sc *recordScanner) next() (ok, head bool) {
+	if sc.done || !sc.Scanner.Scan() {
+		sc.done = true
+		return false, false
+	}
+	head, sc.err = sc.isHead(sc.Scanner.Bytes())
+	if sc.err != nil {
+		sc.done = true
+		return false, false
+	}
+	return true, head
+}
+
+func (sc *recordScanner) Scan() bool {
+	sc.record, sc.count, sc.match = sc.record[:0], 0, false
+	if sc.hasPending {
+		sc.record = append(sc.record, sc.pending...)
+		sc.head, sc.last, sc.count, sc.match = sc.pendingNum, sc.pendingNum, 1, true
+		sc.hasPending = false
+	} else {
+		ok, head := sc.next()
+		if !ok {
+			return false
+		}
+		sc.record = append(sc.record, sc.Scanner.Bytes()...)
+		sc.head, sc.last, sc.count, sc.match = sc.Scanner.NumRead(), sc.Scanner.NumRead(), 1, head
+	}
+	for {
+		ok, head := sc.next()
+		if !ok {
+			if sc.err != nil {
+				sc.count, sc.match = 0, false
+				return false
+			}
+			return true
+		}
+		if head {
+			sc.pending = append(sc.pending[:0], sc.Scanner.Bytes()...)
+			sc.pendingNum, sc.hasPending = sc.Scanner.NumRead(), true
+			return true
+		}
+		sc.record = append(sc.record, '\n')
+		sc.record = append(sc.record, sc.Scanner.Bytes()...)
+		sc.last = sc.Scanner.NumRead()
+		sc.count++
+	}
+}
+
+func (sc *recordScanner) Bytes() []byte {
+	if sc.count == 0 {
+		return nil
+	}
+	return sc.record
+}
+
+func (sc *recordScanner) Text() string {
+	return string(sc.Bytes())
+}
+
+func (sc *recordScanner) IsMatch() bool {
+	return sc.match
+}
+
+func (sc *recordScanner) NumRead() int {
+	if sc.count == 0 {
+		return sc.Scanner.NumRead()
+	}
+	return sc.last
+}
+
+func (sc *recordScanner) HeadNumRead() int {
+	if sc.count == 0 {
+		return 0
+	}
+	return sc.head
+}
+
+func (sc *recordScanner) LineCount() int {
+	return sc.count
+}
+
+func (sc *recordScanner) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
+	return sc.Scanner.Err()
+}
@@ -0,0 +1,104 @@
+package scanio_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+type resultR struct {
+	canParse  bool
+	head      int
+	num       int
+	lineCount int
+	isMatch   bool
+	text      string
+}
+
+const stackTraceLog = `preamble
+INFO started
+ERROR failed
+Traceback (most recent call last):
+  File "x.py", line 1
+INFO done`
+
+func isLogHead(b []byte) (bool, error) {
+	return bytes.HasPrefix(b, []byte("INFO")) || bytes.HasPrefix(b, []byte("ERROR")), nil
+}
+
+func TestRecordScanner(t *testing.T) {
+	scn := scanio.NewRecordScanner(scanio.NewScanner(strings.NewReader(stackTraceLog)), isLogHead)
+
+	expected := []resultR{
+		{true, 1, 1, 1, false, "preamble"},
+		{true, 2, 2, 1, true, "INFO started"},
+		{true, 3, 5, 3, true, "ERROR failed\nTraceback (most recent call last):\n  File \"x.py\", line 1"},
+		{true, 6, 6, 1, true, "INFO done"},
+		{false, 0, 6, 0, false, ""},
+		{false, 0, 6, 0, false, ""},
+	}
+	for _, v := range expected {
+		res := scn.Scan()
+		r := resultR{res, scn.HeadNumRead(), scn.NumRead(), scn.LineCount(), scn.IsMatch(), scn.Text()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestRecordScannerEmpty(t *testing.T) {
+	scn := scanio.NewRecordScanner(scanio.NewScanner(strings.NewReader("")), isLogHead)
+
+	expected := []resultR{
+		{false, 0, 0, 0, false, ""},
+		{false, 0, 0, 0, false, ""},
+	}
+	for _, v := range expected {
+		res := scn.Scan()
+		r := resultR{res, scn.HeadNumRead(), scn.NumRead(), scn.LineCount(), scn.IsMatch(), scn.Text()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestRecordScannerFilter(t *testing.T) {
+	scn := scanio.NewFilterScanner(
+		scanio.NewRecordScanner(scanio.NewScanner(strings.NewReader(stackTraceLog)), isLogHead),
+		func(b []byte) (bool, error) {
+			return bytes.Contains(b, []byte("Traceback")), nil
+		})
+
+	expected := []result{
+		{true, 5, true, "ERROR failed\nTraceback (most recent call last):\n  File \"x.py\", line 1"},
+		{false, 6, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestRecordScannerError(t *testing.T) {
+	errRule := errors.New("rule error")
+	scn := scanio.NewRecordScanner(scanio.NewScanner(strings.NewReader("a\nb\nc")), func(b []byte) (bool, error) {
+		if b[0] == 'c' {
+			return false, errRule
+		}
+		return true, nil
+	})
+
+	count := 0
+	for scn.Scan() {
+		count++
+	}
+	if count != 1 || scn.Err() != errRule {
+		t.Errorf("should be %v, %v, is %v, %v", 1, errRule, count, scn.Err())
+	}
+}
@@ -0,0 +1,151 @@
+package scanio
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+type reverseScanner struct {
+	r         io.ReadSeeker
+	chunkSize int
+	max       int
+	buf       []byte // unread data, ending at the end of the not-yet-returned lines
+	bufStart  int64  // input offset of buf
+	token     []byte
+	started   bool
+	done      bool
+	num       int
+	total     int
+	err       error
+}
+
+// NewReverseScanner creates a Scanner reading lines from the end of the input to its start, as bufio.ScanLines would
+// return them, in reverse order. The input is read backwards in chunks.
+// NumRead counts the lines from the end of the input, so the NumRead of consecutive lines grows by one,
+// as the AheadScanner expects.
+//
+// Split is not supported, the Scanner always reads lines. Buffer sets the chunk size and the maximum line length.
+func NewReverseScanner(r io.ReadSeeker) Scanner {
+	return Scanner(&reverseScanner{
+		r:         r,
+		chunkSize: startBufSize,
+		max:       bufio.MaxScanTokenSize,
+	})
+}
+
+// NewReverseScannerWithTotal creates a Scanner like the NewReverseScanner does, but with NumRead reporting
+// true (forward) line numbers, computed from the total number of lines of the input.
+// As NumRead decreases, the AheadScanner does not recognize its consecutive token sequences.
+func NewReverseScannerWithTotal(r io.ReadSeeker, total int) Scanner {
+	return Scanner(&reverseScanner{
+		r:         r,
+		chunkSize: startBufSize,
+		max:       bufio.MaxScanTokenSize,
+		total:     total,
+	})
+}
+
+// readChunk prepends a chunk of input, preceding the buf, to the buf.
+func (sc *reverseScanner) readChunk() error {
+	n := int64(sc.chunkSize)
+	if n > sc.bufStart {
+		n = sc.bufStart
+	}
+	if len(sc.buf)+int(n) > sc.max {
+		return bufio.ErrTooLong
+	}
+	if _, err := sc.r.Seek(sc.bufStart-n, io.SeekStart); err != nil {
+		return err
+	}
+	chunk := make([]byte, int(n), int(n)+len(sc.buf))
+	if _, err := io.ReadFull(sc.r, chunk); err != nil {
+		return err
+	}
+	sc.buf = append(chunk, sc.buf...)
+	sc.bufStart -= n
+	return nil
+}
+
+func (sc *reverseScanner) Scan() bool {
+	sc.token = nil
+	if sc.done || sc.err != nil {
+		return false
+	}
+	if !sc.started {
+		sc.started = true
+		size, err := sc.r.Seek(0, io.SeekEnd)
+		if err != nil {
+			sc.err = err
+			return false
+		}
+		sc.bufStart = size
+		if size == 0 {
+			sc.done = true
+			return false
+		}
+		if sc.err = sc.readChunk(); sc.err != nil {
+			return false
+		}
+		// the final line ending does not begin an empty line
+		sc.buf = bytes.TrimSuffix(sc.buf, []byte("\n"))
+	}
+	for {
+		if i := bytes.LastIndexByte(sc.buf, '\n'); i >= 0 {
+			sc.token = dropCR(sc.buf[i+1:])
+			sc.buf = sc.buf[:i]
+			break
+		}
+		if sc.bufStart == 0 {
+			// the first line of the input
+			sc.token = dropCR(sc.buf)
+			sc.buf = nil
+			sc.done = true
+			break
+		}
+		if sc.err = sc.readChunk(); sc.err != nil {
+			return false
+		}
+	}
+	sc.num++
+	return true
+}
+
+// dropCR drops a terminal \r from the data.
+func dropCR(data []byte) []byte {
+	return bytes.TrimSuffix(data, []byte("\r"))
+}
+
+func (sc *reverseScanner) Bytes() []byte {
+	return sc.token
+}
+
+func (sc *reverseScanner) Text() string {
+	return string(sc.token)
+}
+
+func (sc *reverseScanner) Err() error {
+	return sc.err
+}
+
+func (sc *reverseScanner) IsMatch() bool {
+	return sc.token != nil
+}
+
+func (sc *reverseScanner) NumRead() int {
+	if sc.total > 0 {
+		return sc.total - sc.num + 1
+	}
+	return sc.num
+}
+
+// Split is not supported, the reverse Scanner always reads lines.
+func (sc *reverseScanner) Split(split bufio.SplitFunc) {
+}
+
+func (sc *reverseScanner) Buffer(buf []byte, max int) {
+	if cap(buf) > 0 {
+		sc.chunkSize = cap(buf)
+	}
+	sc.max = max
+}
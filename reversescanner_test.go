@@ -0,0 +1,90 @@
+package scanio_test
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func TestReverseScanner(t *testing.T) {
+	tests := map[string][]string{
+		"":               nil,
+		"\n":             {""},
+		"a":              {"a"},
+		"a\nb\nc":        {"c", "b", "a"},
+		"a\nb\nc\n":      {"c", "b", "a"},
+		"a\r\nb\r\n\n":   {"", "b", "a"},
+		"\n\na\n":        {"a", "", ""},
+		"one\n\nthree\n": {"three", "", "one"},
+	}
+	for input, want := range tests {
+		scn := scanio.NewReverseScanner(strings.NewReader(input))
+		var got []string
+		for scn.Scan() {
+			got = append(got, scn.Text())
+			if scn.NumRead() != len(got) {
+				t.Errorf("%q: NumRead should be %d, is %d", input, len(got), scn.NumRead())
+			}
+		}
+		if scn.Err() != nil || fmt.Sprint(got) != fmt.Sprint(want) || len(got) != len(want) {
+			t.Errorf("%q: should be %q, is %q, %v", input, want, got, scn.Err())
+		}
+	}
+}
+
+func TestReverseScannerChunks(t *testing.T) {
+	var sb strings.Builder
+	for i := 1; i <= 1000; i++ {
+		fmt.Fprintf(&sb, "line %d\n", i)
+	}
+	scn := scanio.NewReverseScannerWithTotal(strings.NewReader(sb.String()), 1000)
+	scn.Buffer(make([]byte, 0, 7), 64)
+
+	n := 1000
+	for scn.Scan() {
+		if scn.Text() != fmt.Sprintf("line %d", n) || scn.NumRead() != n {
+			t.Fatalf("should be %d %q, is %d %q", n, fmt.Sprintf("line %d", n), scn.NumRead(), scn.Text())
+		}
+		n--
+	}
+	if n != 0 || scn.Err() != nil {
+		t.Errorf("should read all lines, %d left, %v", n, scn.Err())
+	}
+}
+
+func TestReverseScannerTooLong(t *testing.T) {
+	scn := scanio.NewReverseScanner(strings.NewReader("short\n" + strings.Repeat("x", 100) + "\nend"))
+	scn.Buffer(make([]byte, 0, 16), 32)
+
+	if !scn.Scan() || scn.Text() != "end" {
+		t.Errorf("should be %q, is %q", "end", scn.Text())
+	}
+	if scn.Scan() || scn.Err() != bufio.ErrTooLong {
+		t.Errorf("should be %v, is %v", bufio.ErrTooLong, scn.Err())
+	}
+}
+
+func TestReverseScannerRuleAhead(t *testing.T) {
+	scn := scanio.NewAheadScanner(scanio.NewRuleScanner(
+		scanio.NewReverseScanner(strings.NewReader("# c1\ncode\n# c2\n# c3\n")), isComment))
+
+	expected := []struct {
+		resultL
+		isEnd bool
+	}{
+		{resultL{true, 1, true, "# c3", false}, false},
+		{resultL{true, 2, true, "# c2", false}, true},
+		{resultL{true, 3, false, "code", false}, false},
+		{resultL{true, 4, true, "# c1", true}, true},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text, isLast := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text(), scn.IsLast()
+
+		if got := (resultL{res, num, isMatch, text, isLast}); got != v.resultL || scn.IsConsecutiveEnd() != v.isEnd {
+			t.Errorf("should be %v, is %v, %v", v, got, scn.IsConsecutiveEnd())
+		}
+	}
+}
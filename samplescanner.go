@@ -0,0 +1,101 @@
+package scanio
+
+import (
+	"math/rand"
+	"sort"
+)
+
+type everyNthScanner struct {
+	Scanner
+	n     int
+	count int
+}
+
+// NewEveryNthScanner returns a Scanner that outputs every n-th token (n-th, 2n-th, ...).
+// Original NumRead values are preserved.
+func NewEveryNthScanner(sc Scanner, n int) Scanner {
+	if n < 1 {
+		n = 1
+	}
+	return Scanner(&everyNthScanner{
+		Scanner: sc,
+		n:       n,
+	})
+}
+
+func (sc *everyNthScanner) Scan() bool {
+	for sc.Scanner.Scan() {
+		sc.count++
+		if sc.count%sc.n == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+//--------------------------------------------------------------------------------
+
+type randomSampleScanner struct {
+	Scanner
+	p   float64
+	rnd *rand.Rand
+}
+
+// NewRandomSampleScanner returns a Scanner that outputs each token with a probability p.
+// The seed makes the sampling reproducible.
+// Original NumRead values are preserved.
+func NewRandomSampleScanner(sc Scanner, p float64, seed int64) Scanner {
+	return Scanner(&randomSampleScanner{
+		Scanner: sc,
+		p:       p,
+		rnd:     rand.New(rand.NewSource(seed)),
+	})
+}
+
+func (sc *randomSampleScanner) Scan() bool {
+	for sc.Scanner.Scan() {
+		if sc.rnd.Float64() < sc.p {
+			return true
+		}
+	}
+	return false
+}
+
+//--------------------------------------------------------------------------------
+
+// NewReservoirScanner returns an AheadScanner that outputs a uniform random sample of k tokens.
+// The input is read to its end on the first Scan, sampled tokens are then output in their original order.
+// The seed makes the sampling reproducible.
+func NewReservoirScanner(sc Scanner, k int, seed int64) AheadScanner {
+	if k < 0 {
+		k = 0
+	}
+	return NewAheadScanner(&replayScanner{
+		Scanner: sc,
+		collect: reservoirCollector(k, rand.New(rand.NewSource(seed))),
+	})
+}
+
+// reservoirCollector implements the reservoir sampling (Algorithm R).
+func reservoirCollector(k int, rnd *rand.Rand) func(sc Scanner) []*info {
+	return func(sc Scanner) []*info {
+		res := make([]*info, 0, k)
+		seen := 0
+		for sc.Scan() {
+			seen++
+			if len(res) < k {
+				i := &info{}
+				i.update(sc, true)
+				res = append(res, i)
+				continue
+			}
+			if j := rnd.Intn(seen); j < k {
+				res[j].update(sc, true)
+			}
+		}
+		sort.Slice(res, func(a, b int) bool {
+			return res[a].NumRead < res[b].NumRead
+		})
+		return res
+	}
+}
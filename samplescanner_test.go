@@ -0,0 +1,107 @@
+package scanio_test
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func TestEveryNthScanner(t *testing.T) {
+	f := strings.NewReader("1 2 3 4 5 6 7")
+	scn := scanio.NewEveryNthScanner(scanio.NewScanner(f), 3)
+	scn.Split(bufio.ScanWords)
+
+	expected := []result{
+		{true, 3, true, "3"},
+		{true, 6, true, "6"},
+		{false, 7, false, ""},
+		{false, 7, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func sampleNums(sc scanio.Scanner) []int {
+	nums := []int{}
+	for sc.Scan() {
+		nums = append(nums, sc.NumRead())
+	}
+	return nums
+}
+
+func TestRandomSampleScanner(t *testing.T) {
+	const input = "a b c d e f g h i j k l m n o p q r s t u v w x y z"
+	newScanner := func(p float64, seed int64) scanio.Scanner {
+		sc := scanio.NewRandomSampleScanner(scanio.NewScanner(strings.NewReader(input)), p, seed)
+		sc.Split(bufio.ScanWords)
+		return sc
+	}
+
+	if n := len(sampleNums(newScanner(0, 1))); n != 0 {
+		t.Errorf("p=0: should be %v, is %v", 0, n)
+	}
+	if n := len(sampleNums(newScanner(1, 1))); n != 26 {
+		t.Errorf("p=1: should be %v, is %v", 26, n)
+	}
+
+	first, second := sampleNums(newScanner(0.5, 42)), sampleNums(newScanner(0.5, 42))
+	if len(first) != len(second) {
+		t.Errorf("same seed: should be %v, is %v", first, second)
+		return
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("same seed: should be %v, is %v", first, second)
+			return
+		}
+	}
+}
+
+func TestReservoirScanner(t *testing.T) {
+	const input = "a b c d e f g h i j k l m n o p q r s t u v w x y z"
+	scn := scanio.NewReservoirScanner(scanio.NewScanner(strings.NewReader(input)), 5, 7)
+	scn.Split(bufio.ScanWords)
+
+	prev, count := 0, 0
+	for scn.Scan() {
+		count++
+		if scn.NumRead() <= prev {
+			t.Errorf("should be in original order, %d after %d", scn.NumRead(), prev)
+		}
+		if want := string(rune('a' + scn.NumRead() - 1)); scn.Text() != want {
+			t.Errorf("at %d: should be %q, is %q", scn.NumRead(), want, scn.Text())
+		}
+		if scn.IsLast() != (count == 5) {
+			t.Errorf("at %d: IsLast should be %v", count, count == 5)
+		}
+		prev = scn.NumRead()
+	}
+	if count != 5 {
+		t.Errorf("should be %v, is %v", 5, count)
+	}
+}
+
+func TestReservoirScannerShortInput(t *testing.T) {
+	f := strings.NewReader("a\nb")
+	scn := scanio.NewReservoirScanner(scanio.NewScanner(f), 5, 1)
+
+	expected := []resultL{
+		{true, 1, true, "a", false},
+		{true, 2, true, "b", true},
+		{false, 2, false, "", true},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text, isLast := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text(), scn.IsLast()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text || isLast != v.isLast {
+			t.Errorf("should be %v, is %v", v, resultL{res, num, isMatch, text, isLast})
+		}
+	}
+}
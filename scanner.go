@@ -25,17 +25,30 @@ type Scanner interface {
 
 // reader Scanner
 type readerScanner struct {
-	scn   *bufio.Scanner
-	match bool
-	num   int
+	scn    *bufio.Scanner
+	match  bool
+	num    int
+	offset int64 // input offset of the next unread token
+	err    error
 }
 
 // NewScanner creates a new Scanner using a Reader.
 // This Scanner can be used instead of bufio.Scanner
 func NewScanner(r io.Reader) Scanner {
-	return Scanner(&readerScanner{
+	sc := &readerScanner{
 		scn: bufio.NewScanner(r),
-	})
+	}
+	sc.scn.Split(sc.track(bufio.ScanLines))
+	return Scanner(sc)
+}
+
+// track wraps the split function to count the bytes consumed.
+func (sc *readerScanner) track(split bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := split(data, atEOF)
+		sc.offset += int64(advance)
+		return advance, token, err
+	}
 }
 
 func (sc *readerScanner) Buffer(b []byte, max int) {
@@ -43,7 +56,7 @@ func (sc *readerScanner) Buffer(b []byte, max int) {
 }
 
 func (sc *readerScanner) Scan() bool {
-	if sc.scn.Scan() {
+	if sc.err == nil && sc.scn.Scan() {
 		sc.num++
 		sc.match = true
 		return true
@@ -53,11 +66,14 @@ func (sc *readerScanner) Scan() bool {
 }
 
 func (sc *readerScanner) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
 	return sc.scn.Err()
 }
 
 func (sc *readerScanner) Split(split bufio.SplitFunc) {
-	sc.scn.Split(split)
+	sc.scn.Split(sc.track(split))
 }
 
 func (sc *readerScanner) Text() string {
@@ -0,0 +1,139 @@
+package scanio
+
+import (
+	"bufio"
+	"io"
+)
+
+type seekScanner struct {
+	Scanner
+	r       io.ReadSeeker
+	rule    MatchRule
+	split   bufio.SplitFunc
+	buf     []byte
+	max     int
+	started bool
+	err     error
+}
+
+// NewSeekScanner creates a Scanner for sorted, seekable inputs, such as multi-GB time-sorted logs.
+// The rule must be monotonic: not matching for tokens at the beginning of the input, matching for all tokens after them.
+// On the first Scan, byte offsets of the input are binary-searched and the scanning starts at the first matching token,
+// so only a few tokens before it are read.
+//
+// After a seek, the input is resynchronized to the next token boundary by the split function,
+// so the split function must find token boundaries from any position, as bufio.ScanLines does.
+// NumRead counts the tokens from the first matching one.
+func NewSeekScanner(r io.ReadSeeker, rule MatchRule) Scanner {
+	return Scanner(&seekScanner{
+		Scanner: NewScanner(r),
+		r:       r,
+		rule:    rule,
+		split:   bufio.ScanLines,
+		max:     bufio.MaxScanTokenSize,
+	})
+}
+
+func (sc *seekScanner) Split(split bufio.SplitFunc) {
+	sc.Scanner.Split(split)
+	sc.split = split
+}
+
+func (sc *seekScanner) Buffer(buf []byte, max int) {
+	sc.Scanner.Buffer(buf, max)
+	sc.buf, sc.max = buf, max
+}
+
+// tokenAfter returns the first token beginning at the offset or after it, and an offset to resume the scanning at that token.
+func (sc *seekScanner) tokenAfter(offset int64) (start int64, token []byte, ok bool, err error) {
+	base := offset - 1
+	if offset == 0 {
+		base = 0
+	}
+	if _, err := sc.r.Seek(base, io.SeekStart); err != nil {
+		return 0, nil, false, err
+	}
+	pos := base
+	s := bufio.NewScanner(sc.r)
+	if sc.buf != nil {
+		s.Buffer(make([]byte, 0, cap(sc.buf)), sc.max)
+	}
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, tok, err := sc.split(data, atEOF)
+		if tok != nil {
+			start = pos
+		}
+		pos += int64(advance)
+		return advance, tok, err
+	})
+	if offset > 0 && !s.Scan() {
+		// the token at base is partial, or a separator only
+		return 0, nil, false, s.Err()
+	}
+	if !s.Scan() {
+		return 0, nil, false, s.Err()
+	}
+	return start, s.Bytes(), true, nil
+}
+
+// matchesAfter tells whether the first token beginning at the offset or after it matches the rule.
+// There being no such token counts as a match, as the end of input is after all tokens.
+func (sc *seekScanner) matchesAfter(offset int64) (bool, error) {
+	_, token, ok, err := sc.tokenAfter(offset)
+	if err != nil || !ok {
+		return true, err
+	}
+	return sc.rule(token)
+}
+
+// seek finds the offset of the first matching token, or the end of input.
+func (sc *seekScanner) seek() (int64, error) {
+	size, err := sc.r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	// binary search for the smallest offset in [0, size] where the token after it matches,
+	// over int64 offsets, so inputs larger than int can hold are searched whole
+	lo, hi := int64(0), size+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		matched, err := sc.matchesAfter(mid)
+		if err != nil {
+			return 0, err
+		}
+		if matched {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	start, _, ok, err := sc.tokenAfter(lo)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return size, nil
+	}
+	return start, nil
+}
+
+func (sc *seekScanner) Scan() bool {
+	if !sc.started {
+		sc.started = true
+		var start int64
+		if start, sc.err = sc.seek(); sc.err == nil {
+			_, sc.err = sc.r.Seek(start, io.SeekStart)
+		}
+	}
+	if sc.err != nil {
+		return false
+	}
+	return sc.Scanner.Scan()
+}
+
+func (sc *seekScanner) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
+	return sc.Scanner.Err()
+}
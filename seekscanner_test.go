@@ -0,0 +1,114 @@
+package scanio_test
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func atLeast(n int) scanio.MatchRule {
+	return func(b []byte) (bool, error) {
+		v, err := strconv.Atoi(strings.Fields(string(b))[0])
+		return v >= n, err
+	}
+}
+
+// countingReadSeeker counts the bytes read.
+type countingReadSeeker struct {
+	io.ReadSeeker
+	n int
+}
+
+func (r *countingReadSeeker) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	r.n += n
+	return n, err
+}
+
+func TestSeekScanner(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i < 200; i += 2 {
+		fmt.Fprintf(&sb, "%d line %d\n", i, i)
+	}
+	input := sb.String()
+
+	for _, target := range []int{-1, 0, 1, 2, 57, 100, 197, 198, 199, 500} {
+		scn := scanio.NewSeekScanner(strings.NewReader(input), atLeast(target))
+
+		want := target
+		if want < 0 {
+			want = 0
+		}
+		want += want % 2
+		if want >= 200 {
+			if scn.Scan() {
+				t.Errorf("%d: should be the end, is %q", target, scn.Text())
+			}
+			continue
+		}
+		if !scn.Scan() || scn.Text() != fmt.Sprintf("%d line %d", want, want) || scn.NumRead() != 1 {
+			t.Errorf("%d: should be %d, is %d %q, %v", target, want, scn.NumRead(), scn.Text(), scn.Err())
+			continue
+		}
+		if want+2 < 200 && (!scn.Scan() || scn.Text() != fmt.Sprintf("%d line %d", want+2, want+2)) {
+			t.Errorf("%d: next should be %d, is %q", target, want+2, scn.Text())
+		}
+	}
+}
+
+func TestSeekScannerWords(t *testing.T) {
+	scn := scanio.NewSeekScanner(strings.NewReader("1 3  5 7\n9 11 13"), atLeast(6))
+	scn.Split(bufio.ScanWords)
+
+	expected := []result{
+		{true, 1, true, "7"},
+		{true, 2, true, "9"},
+		{true, 3, true, "11"},
+		{true, 4, true, "13"},
+		{false, 4, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+}
+
+func TestSeekScannerReadsLittle(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i < 100000; i++ {
+		fmt.Fprintf(&sb, "%06d\n", i)
+	}
+	r := &countingReadSeeker{ReadSeeker: strings.NewReader(sb.String())}
+	scn := scanio.NewSeekScanner(r, atLeast(99990))
+	scn.Buffer(make([]byte, 0, 64), 64)
+
+	count := 0
+	for scn.Scan() {
+		count++
+	}
+	if count != 10 || scn.Err() != nil {
+		t.Errorf("should be %v, is %v, %v", 10, count, scn.Err())
+	}
+	if r.n > sb.Len()/10 {
+		t.Errorf("should read a small part of %d bytes, has read %d", sb.Len(), r.n)
+	}
+}
+
+func TestSeekScannerError(t *testing.T) {
+	errRule := errors.New("rule error")
+	scn := scanio.NewSeekScanner(strings.NewReader("1\n2\n3"), func(b []byte) (bool, error) {
+		return false, errRule
+	})
+	if scn.Scan() || scn.Err() != errRule {
+		t.Errorf("should be %v, is %v", errRule, scn.Err())
+	}
+}
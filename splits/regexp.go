@@ -0,0 +1,82 @@
+package splits
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+)
+
+// ScanRegexpDelimited returns a split function that returns each token separated by a match of re,
+// with the match stripped. Empty matches are ignored.
+//
+// Unless at the end of input, a match touching the end of the buffered data is not trusted,
+// as more data could extend it. So the delimiter is always matched as a whole, even if it
+// straddles the buffer boundary.
+func ScanRegexpDelimited(re *regexp.Regexp) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		for start := 0; start < len(data); {
+			loc := re.FindIndex(data[start:])
+			if loc == nil {
+				break
+			}
+			if loc[0] == loc[1] {
+				// skip the empty match
+				start += loc[1] + 1
+				continue
+			}
+			begin, end := start+loc[0], start+loc[1]
+			if end == len(data) && !atEOF {
+				break
+			}
+			return end, data[0:begin], nil
+		}
+		if atEOF {
+			return len(data), data, nil
+		}
+		// Request more data.
+		return 0, nil, nil
+	}
+}
+
+// ScanRecordStartingWith returns a split function that returns each record, a group of lines
+// beginning with a line matched by re. The re is tried on each line separately, so "^" matches at the line start.
+// Lines before the first matching line form a record of their own.
+// The token has its trailing line ending stripped, line endings inside the record are kept.
+//
+// Useful for multi-line log entries, such as:
+//
+//	splits.ScanRecordStartingWith(regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `))
+func ScanRecordStartingWith(re *regexp.Regexp) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		i := bytes.IndexByte(data, '\n')
+		for i >= 0 {
+			lineStart := i + 1
+			lineLen := bytes.IndexByte(data[lineStart:], '\n')
+			if lineLen < 0 {
+				if !atEOF {
+					// the line is not complete yet
+					break
+				}
+				lineLen = len(data) - lineStart
+			}
+			if lineLen > 0 && re.Match(dropCR(data[lineStart:lineStart+lineLen])) {
+				return lineStart, dropCR(data[0:i]), nil
+			}
+			if lineStart+lineLen == len(data) {
+				break
+			}
+			i = lineStart + lineLen
+		}
+		if atEOF {
+			return len(data), dropCR(bytes.TrimSuffix(data, []byte("\n"))), nil
+		}
+		// Request more data.
+		return 0, nil, nil
+	}
+}
@@ -0,0 +1,92 @@
+package splits_test
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomaskraus/scanio"
+	"github.com/tomaskraus/scanio/splits"
+)
+
+func TestScanRegexpDelimited(t *testing.T) {
+	testSplit(t, "regexp", splits.ScanRegexpDelimited(regexp.MustCompile(`,\s*|;;`)), []splitTest{
+		{"", []string{}},
+		{"a", []string{"a"}},
+		{"a,b", []string{"a", "b"}},
+		{"a,   b;;c", []string{"a", "b", "c"}},
+		{"a, \n b,", []string{"a", "b"}},
+		{"a;b;;", []string{"a;b"}},
+	})
+}
+
+func TestScanRegexpDelimitedEmptyMatch(t *testing.T) {
+	testSplit(t, "regexp empty", splits.ScanRegexpDelimited(regexp.MustCompile(`-*`)), []splitTest{
+		{"a--b-c", []string{"a", "b", "c"}},
+	})
+}
+
+func TestScanRegexpDelimitedLarge(t *testing.T) {
+	input := strings.Repeat("abcdefgh,", 200000)
+	sc := scanio.NewScanner(strings.NewReader(input))
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024)
+	sc.Split(splits.ScanRegexpDelimited(regexp.MustCompile(`,`)))
+
+	start := time.Now()
+	n := 0
+	for sc.Scan() {
+		if sc.Text() != "abcdefgh" {
+			t.Fatalf("%d: should be %q, is %q", n, "abcdefgh", sc.Text())
+		}
+		n++
+	}
+	if n != 200000 || sc.Err() != nil {
+		t.Errorf("should be %d, is %d, %v", 200000, n, sc.Err())
+	}
+	// a scan of the whole buffer per token used to take minutes
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("should be fast, took %v", d)
+	}
+}
+
+const logInput = `2023-01-02 10:00:00 INFO started
+2023-01-02 10:00:01 ERROR failed
+java.lang.NullPointerException
+	at Foo.bar(Foo.java:10)
+2023-01-02 10:00:02 INFO done
+`
+
+func TestScanRecordStartingWith(t *testing.T) {
+	split := splits.ScanRecordStartingWith(regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `))
+	testSplit(t, "record", split, []splitTest{
+		{"", []string{}},
+		{"a", []string{"a"}},
+		{"preamble\n2023-01-01 x\n", []string{"preamble", "2023-01-01 x"}},
+		{"2023-01-01 x\r\n y\r\n2023-01-02 z", []string{"2023-01-01 x\r\n y", "2023-01-02 z"}},
+		{"2023-01-01 x\n\n\n2023-01-02 y\n\n", []string{"2023-01-01 x\n\n", "2023-01-02 y\n"}},
+		{logInput, []string{
+			"2023-01-02 10:00:00 INFO started",
+			"2023-01-02 10:00:01 ERROR failed\njava.lang.NullPointerException\n\tat Foo.bar(Foo.java:10)",
+			"2023-01-02 10:00:02 INFO done",
+		}},
+	})
+}
+
+func TestScanRecordStartingWithFilter(t *testing.T) {
+	sc := scanio.NewFilterScanner(scanio.NewScanner(strings.NewReader(logInput)), func(b []byte) (bool, error) {
+		return bytes.Contains(b, []byte("Exception")), nil
+	})
+	sc.Split(splits.ScanRecordStartingWith(regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `)))
+
+	expected := []string{"2023-01-02 10:00:01 ERROR failed\njava.lang.NullPointerException\n\tat Foo.bar(Foo.java:10)"}
+	for _, v := range expected {
+		if !sc.Scan() || sc.Text() != v {
+			t.Errorf("should be %q, is %q", v, sc.Text())
+		}
+	}
+	if sc.Scan() {
+		t.Errorf("should be the end, is %q", sc.Text())
+	}
+}
@@ -0,0 +1,183 @@
+// Package splits provides bufio.SplitFunc functions for common formats.
+// They can be used with both bufio.Scanner and scanio.Scanner.
+package splits
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// ErrOpenQuote is returned by ScanCSVRecords if the input ends inside a quoted field.
+var ErrOpenQuote = errors.New("splits: input ends inside a quoted field")
+
+// dropCR drops a terminal \r from the data.
+func dropCR(data []byte) []byte {
+	if len(data) > 0 && data[len(data)-1] == '\r' {
+		return data[0 : len(data)-1]
+	}
+	return data
+}
+
+// ScanCSVRecords is a split function that returns each CSV record (RFC 4180), with the line ending stripped.
+// A quoted field can contain line endings, those do not end the record.
+// The record is returned as is, its fields are not parsed.
+func ScanCSVRecords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	quoted := false
+	for i, b := range data {
+		switch {
+		case b == '"':
+			// an escaped quote ("") toggles twice
+			quoted = !quoted
+		case b == '\n' && !quoted:
+			return i + 1, dropCR(data[0:i]), nil
+		}
+	}
+	if atEOF {
+		if quoted {
+			return 0, nil, ErrOpenQuote
+		}
+		return len(data), dropCR(data), nil
+	}
+	// Request more data.
+	return 0, nil, nil
+}
+
+// ScanNullTerminated is a split function that returns each NUL-terminated token, with the NUL stripped.
+// Suitable for the output of "find -print0" or "xargs -0" input.
+// The last non-empty token does not need to be terminated.
+func ScanNullTerminated(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return ScanDelimited([]byte{0})(data, atEOF)
+}
+
+// ScanDelimited returns a split function that returns each token separated by sep, with sep stripped.
+// The sep can be longer than one byte, it must not be empty.
+// The last non-empty token does not need to be followed by sep.
+func ScanDelimited(sep []byte) bufio.SplitFunc {
+	if len(sep) == 0 {
+		panic("splits: empty separator")
+	}
+	sep = append([]byte(nil), sep...)
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		if i := bytes.Index(data, sep); i >= 0 {
+			return i + len(sep), data[0:i], nil
+		}
+		if atEOF {
+			return len(data), data, nil
+		}
+		// Request more data.
+		return 0, nil, nil
+	}
+}
+
+// isBlank returns true if the line contains only white space.
+func isBlank(line []byte) bool {
+	return len(bytes.TrimSpace(line)) == 0
+}
+
+// ScanParagraphs is a split function that returns each paragraph, a group of lines separated by blank lines.
+// A blank line is empty or contains only white space. The token has its trailing line ending stripped,
+// line endings inside the paragraph are kept.
+func ScanParagraphs(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// skip leading blank lines
+	start := 0
+	for {
+		i := bytes.IndexByte(data[start:], '\n')
+		if i < 0 {
+			if atEOF && isBlank(data[start:]) {
+				return len(data), nil, nil
+			}
+			break
+		}
+		if !isBlank(data[start : start+i]) {
+			break
+		}
+		start += i + 1
+	}
+	// find the first blank line after the paragraph
+	end := start
+	for {
+		i := bytes.IndexByte(data[end:], '\n')
+		if i < 0 {
+			if atEOF {
+				if end < len(data) && !isBlank(data[end:]) {
+					return len(data), dropCR(data[start:]), nil
+				}
+				if end == start {
+					return len(data), nil, nil
+				}
+				return len(data), dropCR(data[start : end-1]), nil
+			}
+			// Request more data.
+			return start, nil, nil
+		}
+		if isBlank(data[end:end+i]) && end > start {
+			return end + i + 1, dropCR(data[start : end-1]), nil
+		}
+		end += i + 1
+	}
+}
+
+// isSentenceEnd returns true for a sentence-terminating punctuation.
+func isSentenceEnd(b byte) bool {
+	return b == '.' || b == '!' || b == '?'
+}
+
+// isClosing returns true for a character that can follow the sentence-terminating punctuation.
+func isClosing(b byte) bool {
+	return b == '"' || b == '\'' || b == ')' || b == ']'
+}
+
+const spaces = " \t\n\r\v\f"
+
+func isSpace(b byte) bool {
+	return strings.IndexByte(spaces, b) >= 0
+}
+
+// ScanSentences is a split function that returns each sentence, with surrounding white space stripped.
+// A sentence ends with a run of '.', '!' or '?', optionally followed by closing quotes or brackets,
+// and then by white space or the end of input.
+func ScanSentences(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := 0
+	for start < len(data) && isSpace(data[start]) {
+		start++
+	}
+	for i := start; i < len(data); i++ {
+		if !isSentenceEnd(data[i]) {
+			continue
+		}
+		j := i + 1
+		for j < len(data) && isSentenceEnd(data[j]) {
+			j++
+		}
+		for j < len(data) && isClosing(data[j]) {
+			j++
+		}
+		if j == len(data) {
+			if !atEOF {
+				// Request more data.
+				return start, nil, nil
+			}
+			return j, data[start:j], nil
+		}
+		if isSpace(data[j]) {
+			return j + 1, data[start:j], nil
+		}
+		i = j - 1
+	}
+	if atEOF {
+		if start == len(data) {
+			return len(data), nil, nil
+		}
+		return len(data), bytes.TrimRight(data[start:], spaces), nil
+	}
+	// Request more data.
+	return start, nil, nil
+}
@@ -0,0 +1,123 @@
+package splits_test
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/tomaskraus/scanio"
+	"github.com/tomaskraus/scanio/splits"
+)
+
+type splitTest struct {
+	input  string
+	tokens []string
+}
+
+// testSplit runs the split function over a plain reader and over a one-byte reader,
+// so the tokens straddle the buffer boundaries.
+func testSplit(t *testing.T, name string, split bufio.SplitFunc, tests []splitTest) {
+	for _, tt := range tests {
+		readers := map[string]io.Reader{
+			"plain":   strings.NewReader(tt.input),
+			"onebyte": iotest.OneByteReader(strings.NewReader(tt.input)),
+		}
+		for rname, r := range readers {
+			sc := scanio.NewScanner(r)
+			sc.Split(split)
+			tokens := []string{}
+			for sc.Scan() {
+				tokens = append(tokens, sc.Text())
+			}
+			if sc.Err() != nil {
+				t.Errorf("%s %s %q: %v", name, rname, tt.input, sc.Err())
+				continue
+			}
+			if strings.Join(tokens, "|") != strings.Join(tt.tokens, "|") || len(tokens) != len(tt.tokens) {
+				t.Errorf("%s %s %q: should be %q, is %q", name, rname, tt.input, tt.tokens, tokens)
+			}
+		}
+	}
+}
+
+func TestScanCSVRecords(t *testing.T) {
+	testSplit(t, "csv", splits.ScanCSVRecords, []splitTest{
+		{"", []string{}},
+		{"a,b", []string{"a,b"}},
+		{"a,b\n", []string{"a,b"}},
+		{"a,b\r\nc,d\r\n", []string{"a,b", "c,d"}},
+		{"\n\n", []string{"", ""}},
+		{"a,\"b\nc\",d\ne,f", []string{"a,\"b\nc\",d", "e,f"}},
+		{"\"a \"\"quoted\"\"\nword\",b\n", []string{"\"a \"\"quoted\"\"\nword\",b"}},
+		{"\"\",\"\"\n", []string{"\"\",\"\""}},
+	})
+}
+
+func TestScanCSVRecordsOpenQuote(t *testing.T) {
+	sc := scanio.NewScanner(strings.NewReader("a,b\n\"c,d\n"))
+	sc.Split(splits.ScanCSVRecords)
+	tokens := 0
+	for sc.Scan() {
+		tokens++
+	}
+	if tokens != 1 || sc.Err() != splits.ErrOpenQuote {
+		t.Errorf("should be %v, %v, is %v, %v", 1, splits.ErrOpenQuote, tokens, sc.Err())
+	}
+}
+
+func TestScanNullTerminated(t *testing.T) {
+	testSplit(t, "nul", splits.ScanNullTerminated, []splitTest{
+		{"", []string{}},
+		{"a", []string{"a"}},
+		{"a\x00", []string{"a"}},
+		{"a b\x00c\nd\x00", []string{"a b", "c\nd"}},
+		{"\x00\x00", []string{"", ""}},
+	})
+}
+
+func TestScanDelimited(t *testing.T) {
+	testSplit(t, "delimited", splits.ScanDelimited([]byte("<>")), []splitTest{
+		{"", []string{}},
+		{"a", []string{"a"}},
+		{"a<>", []string{"a"}},
+		{"a<b<>c>d<><>e", []string{"a<b", "c>d", "", "e"}},
+		{"<", []string{"<"}},
+		{strings.Repeat("x", 5000) + "<>y", []string{strings.Repeat("x", 5000), "y"}},
+	})
+}
+
+func TestScanDelimitedEmptySep(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("should panic")
+		}
+	}()
+	splits.ScanDelimited(nil)
+}
+
+func TestScanParagraphs(t *testing.T) {
+	testSplit(t, "paragraphs", splits.ScanParagraphs, []splitTest{
+		{"", []string{}},
+		{"\n \n\t\n", []string{}},
+		{"a", []string{"a"}},
+		{"a\nb\n", []string{"a\nb"}},
+		{"a\nb\n\nc\n", []string{"a\nb", "c"}},
+		{"\n\na\n  \n\n\nb\nc", []string{"a", "b\nc"}},
+		{"a\r\nb\r\n\r\nc\r\n", []string{"a\r\nb", "c"}},
+		{"a\n\n  ", []string{"a"}},
+	})
+}
+
+func TestScanSentences(t *testing.T) {
+	testSplit(t, "sentences", splits.ScanSentences, []splitTest{
+		{"", []string{}},
+		{"  \n", []string{}},
+		{"One", []string{"One"}},
+		{"One. Two! Three?", []string{"One.", "Two!", "Three?"}},
+		{"  Wait... what?!  Ok. ", []string{"Wait...", "what?!", "Ok."}},
+		{"Pi is 3.14 exactly. (Really.) \"Yes.\"\nDone", []string{"Pi is 3.14 exactly.", "(Really.)", "\"Yes.\"", "Done"}},
+		{"Line\nbreak. End  ", []string{"Line\nbreak.", "End"}},
+	})
+}
@@ -0,0 +1,194 @@
+package scanio
+
+import (
+	"bufio"
+	"errors"
+	"sync"
+)
+
+// ErrTeeOverflow is returned by a Tee Scanner with the TeeError policy, if it gets too far ahead of the slowest one.
+var ErrTeeOverflow = errors.New("tee buffer overflow")
+
+// TeePolicy tells what a Tee Scanner does if it gets too far ahead of the slowest one.
+type TeePolicy int
+
+const (
+	TeeBlock TeePolicy = iota // Scan waits until the slowest Scanner reads some buffered tokens
+	TeeError                  // Scan returns false, Err returns ErrTeeOverflow
+)
+
+const defaultTeeLimit = 1024
+
+// tee is a source shared by Tee Scanners.
+type tee struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	sc      Scanner
+	buf     []*info // tokens not read by the slowest Scanner yet
+	base    int     // index of the buf[0] token
+	pos     []int   // index of the next token of each Scanner
+	failed  []bool  // Scanners out of the tee, for the TeeError policy
+	end     *info   // the state after the end of input
+	reading bool    // a Scanner reads the source, without holding the lock
+	limit   int
+	policy  TeePolicy
+}
+
+// Tee returns n Scanners, each of them scanning all tokens of the sc, which is read once.
+// The Scanners can be consumed concurrently, with at most 1024 tokens buffered between the fastest and the slowest one.
+// The fastest Scanner blocks then, so the Scanners consumed by a single goroutine one after another would deadlock
+// on a longer input.
+func Tee(sc Scanner, n int) []Scanner {
+	return TeeBounded(sc, n, defaultTeeLimit, TeeBlock)
+}
+
+// TeeBounded returns n Scanners as the Tee does, with at most limit tokens buffered and the policy applied
+// if a Scanner gets further ahead of the slowest one.
+// With the TeeError policy, the failed Scanner does not hold the other ones back anymore.
+// A limit less than 1 means 1.
+func TeeBounded(sc Scanner, n, limit int, policy TeePolicy) []Scanner {
+	if limit < 1 {
+		limit = 1
+	}
+	t := &tee{
+		sc:     sc,
+		pos:    make([]int, n),
+		failed: make([]bool, n),
+		limit:  limit,
+		policy: policy,
+	}
+	t.cond = sync.NewCond(&t.mu)
+	scs := make([]Scanner, n)
+	for i := range scs {
+		scs[i] = Scanner(&teeScanner{tee: t, id: i})
+	}
+	return scs
+}
+
+// next returns the next token for a Scanner.
+func (t *tee) next(id int) (*info, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for {
+		if k := t.pos[id] - t.base; k < len(t.buf) {
+			i := t.buf[k]
+			t.pos[id]++
+			t.trim()
+			return i, nil
+		}
+		if t.end != nil {
+			return t.end, nil
+		}
+		if len(t.buf) >= t.limit {
+			if t.policy == TeeError {
+				// do not hold the other Scanners back
+				t.failed[id] = true
+				t.trim()
+				return nil, ErrTeeOverflow
+			}
+			t.cond.Wait()
+			continue
+		}
+		if t.reading {
+			// wait for the token being read by another Scanner
+			t.cond.Wait()
+			continue
+		}
+		// read without the lock, so other Scanners can get buffered tokens meanwhile
+		t.reading = true
+		t.mu.Unlock()
+		i := &info{}
+		i.update(t.sc, t.sc.Scan())
+		t.mu.Lock()
+		t.reading = false
+		if i.ScanRes {
+			t.buf = append(t.buf, i)
+		} else {
+			t.end = i
+		}
+		t.cond.Broadcast()
+	}
+}
+
+// trim drops tokens read by all Scanners.
+func (t *tee) trim() {
+	min := -1
+	for id, p := range t.pos {
+		if !t.failed[id] && (min < 0 || p < min) {
+			min = p
+		}
+	}
+	if min > t.base {
+		t.buf = t.buf[min-t.base:]
+		t.base = min
+		t.cond.Broadcast()
+	}
+}
+
+type teeScanner struct {
+	tee *tee
+	id  int
+	cur *info
+	err error
+}
+
+func (sc *teeScanner) Scan() bool {
+	if sc.err != nil {
+		return false
+	}
+	sc.cur, sc.err = sc.tee.next(sc.id)
+	if sc.err != nil {
+		sc.cur = nil
+		return false
+	}
+	return sc.cur.ScanRes
+}
+
+// Buffer sets the buffer of the shared source Scanner.
+func (sc *teeScanner) Buffer(buf []byte, max int) {
+	sc.tee.mu.Lock()
+	defer sc.tee.mu.Unlock()
+	sc.tee.sc.Buffer(buf, max)
+}
+
+// Split sets the split function of the shared source Scanner.
+func (sc *teeScanner) Split(split bufio.SplitFunc) {
+	sc.tee.mu.Lock()
+	defer sc.tee.mu.Unlock()
+	sc.tee.sc.Split(split)
+}
+
+func (sc *teeScanner) Text() string {
+	if sc.cur == nil {
+		return ""
+	}
+	return sc.cur.Text
+}
+
+func (sc *teeScanner) Bytes() []byte {
+	if sc.cur == nil {
+		return nil
+	}
+	return sc.cur.Bytes
+}
+
+func (sc *teeScanner) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
+	if sc.cur == nil {
+		return nil
+	}
+	return sc.cur.Err
+}
+
+func (sc *teeScanner) IsMatch() bool {
+	return sc.cur != nil && sc.cur.IsMatch
+}
+
+func (sc *teeScanner) NumRead() int {
+	if sc.cur == nil {
+		return 0
+	}
+	return sc.cur.NumRead
+}
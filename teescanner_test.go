@@ -0,0 +1,158 @@
+package scanio_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func TestTee(t *testing.T) {
+	var sb strings.Builder
+	for i := 1; i <= 5000; i++ {
+		if i%10 == 0 {
+			fmt.Fprintf(&sb, "# comment %d\n", i)
+		} else {
+			fmt.Fprintf(&sb, "line %d\n", i)
+		}
+	}
+	scs := scanio.Tee(scanio.NewScanner(strings.NewReader(sb.String())), 3)
+
+	counts := make([]int, 3)
+	last := make([]int, 3)
+	chains := []scanio.Scanner{
+		scs[0],
+		scanio.NewFilterScanner(scs[1], isComment),
+		scanio.NewOnlyNotMatchScanner(scanio.NewRuleScanner(scs[2], isComment)),
+	}
+	var wg sync.WaitGroup
+	for i, scn := range chains {
+		wg.Add(1)
+		go func(i int, scn scanio.Scanner) {
+			defer wg.Done()
+			for scn.Scan() {
+				counts[i]++
+				last[i] = scn.NumRead()
+			}
+		}(i, scn)
+	}
+	wg.Wait()
+
+	if counts[0] != 5000 || counts[1] != 500 || counts[2] != 4500 {
+		t.Errorf("should be %v, is %v", []int{5000, 500, 4500}, counts)
+	}
+	if last[0] != 5000 || last[1] != 5000 || last[2] != 4999 {
+		t.Errorf("should be %v, is %v", []int{5000, 5000, 4999}, last)
+	}
+}
+
+func TestTeeError(t *testing.T) {
+	scs := scanio.TeeBounded(scanio.NewScanner(strings.NewReader("1\n2\n3\n4\n5")), 2, 2, scanio.TeeError)
+
+	scanResult(scs[0])
+	scanResult(scs[0])
+	if got := scanResult(scs[0]); got != (result{false, 0, false, ""}) || scs[0].Err() != scanio.ErrTeeOverflow {
+		t.Errorf("should be %v, is %v, %v", scanio.ErrTeeOverflow, got, scs[0].Err())
+	}
+	expected := []result{
+		{true, 1, true, "1"},
+		{true, 2, true, "2"},
+		{true, 3, true, "3"},
+		{true, 4, true, "4"},
+		{true, 5, true, "5"},
+		{false, 5, false, ""},
+	}
+	for _, v := range expected {
+		if got := scanResult(scs[1]); got != v {
+			t.Errorf("should be %v, is %v", v, got)
+		}
+	}
+}
+
+func TestTeeBlock(t *testing.T) {
+	scs := scanio.TeeBounded(scanio.NewScanner(strings.NewReader("1\n2\n3\n4")), 2, 1, scanio.TeeBlock)
+
+	done := make(chan []string)
+	go func() {
+		var got []string
+		for scs[0].Scan() {
+			got = append(got, scs[0].Text())
+		}
+		done <- got
+	}()
+	var got []string
+	for scs[1].Scan() {
+		got = append(got, scs[1].Text())
+	}
+	if fast := <-done; fmt.Sprint(fast) != "[1 2 3 4]" || fmt.Sprint(got) != "[1 2 3 4]" {
+		t.Errorf("should be %v, is %v, %v", "[1 2 3 4]", fast, got)
+	}
+}
+
+func TestTeeZeroLimit(t *testing.T) {
+	for _, policy := range []scanio.TeePolicy{scanio.TeeBlock, scanio.TeeError} {
+		scs := scanio.TeeBounded(scanio.NewScanner(strings.NewReader("1\n2")), 1, 0, policy)
+
+		expected := []result{
+			{true, 1, true, "1"},
+			{true, 2, true, "2"},
+			{false, 2, false, ""},
+		}
+		for _, v := range expected {
+			if got := scanResult(scs[0]); got != v {
+				t.Errorf("%v: should be %v, is %v", policy, v, got)
+			}
+		}
+	}
+}
+
+// signalingReader signals each Read call.
+type signalingReader struct {
+	io.Reader
+	reads chan struct{}
+}
+
+func (r *signalingReader) Read(p []byte) (int, error) {
+	r.reads <- struct{}{}
+	return r.Reader.Read(p)
+}
+
+func TestTeeReadWithoutLock(t *testing.T) {
+	r, w := io.Pipe()
+	sr := &signalingReader{Reader: r, reads: make(chan struct{}, 10)}
+	scs := scanio.Tee(scanio.NewScanner(sr), 2)
+
+	go w.Write([]byte("1\n2\n"))
+	scanResult(scs[0])
+	scanResult(scs[0])
+	<-sr.reads
+	fast := make(chan result)
+	go func() {
+		fast <- scanResult(scs[0])
+	}()
+	// the fast Scanner blocks reading the source
+	<-sr.reads
+
+	slow := make(chan []result)
+	go func() {
+		slow <- []result{scanResult(scs[1]), scanResult(scs[1])}
+	}()
+	select {
+	case got := <-slow:
+		if fmt.Sprint(got) != fmt.Sprint([]result{{true, 1, true, "1"}, {true, 2, true, "2"}}) {
+			t.Errorf("should be the buffered tokens, is %v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("should get the buffered tokens while the source is being read")
+	}
+
+	w.Write([]byte("3\n"))
+	w.Close()
+	if got, v := <-fast, (result{true, 3, true, "3"}); got != v {
+		t.Errorf("should be %v, is %v", v, got)
+	}
+}
@@ -0,0 +1,156 @@
+package scanio
+
+import (
+	"regexp"
+	"time"
+)
+
+// TimeExtractor finds a timestamp in a token. Returns false if there is none.
+type TimeExtractor func(token []byte) (time.Time, bool)
+
+// LayoutTimeExtractor returns a TimeExtractor for timestamps at the beginning of a token, in the layout (as time.Parse uses).
+// The timestamp ends at a space or at the end of the token. Timestamps without a time zone are in the loc, UTC if loc is nil.
+func LayoutTimeExtractor(layout string, loc *time.Location) TimeExtractor {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return func(token []byte) (time.Time, bool) {
+		// the layout itself can contain spaces, so try all of them
+		for end := 0; end <= len(token); end++ {
+			if end < len(token) && token[end] != ' ' {
+				continue
+			}
+			if t, err := time.ParseInLocation(layout, string(token[:end]), loc); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}
+}
+
+type timeFormat struct {
+	re      *regexp.Regexp
+	layouts []string
+}
+
+var timeFormats = []timeFormat{
+	// RFC 3339, also with a space instead of 'T', without a time zone
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?`),
+		[]string{time.RFC3339Nano, "2006-01-02 15:04:05Z07:00", "2006-01-02T15:04:05", "2006-01-02 15:04:05"}},
+	// Apache common log
+	{regexp.MustCompile(`\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`),
+		[]string{"02/Jan/2006:15:04:05 -0700"}},
+	// syslog, without a year
+	{regexp.MustCompile(`[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}`),
+		[]string{time.Stamp}},
+}
+
+// AutoTimeExtractor returns a TimeExtractor which recognizes RFC 3339, Apache common log and syslog timestamps,
+// anywhere in the token. Syslog timestamps get the year. Timestamps without a time zone are in the loc, UTC if loc is nil.
+func AutoTimeExtractor(year int, loc *time.Location) TimeExtractor {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return func(token []byte) (time.Time, bool) {
+		for _, f := range timeFormats {
+			s := f.re.Find(token)
+			if s == nil {
+				continue
+			}
+			for _, layout := range f.layouts {
+				t, err := time.ParseInLocation(layout, string(s), loc)
+				if err != nil {
+					continue
+				}
+				if t.Year() == 0 {
+					t = t.AddDate(year, 0, 0)
+				}
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}
+}
+
+// NewTimeScanner creates a TypedScanner with timestamps of tokens as values.
+// A token without a timestamp has a zero time value, it does not stop the scanning.
+func NewTimeScanner(sc Scanner, extract TimeExtractor) TypedScanner[time.Time] {
+	return NewTypedScanner(sc, func(token []byte) (time.Time, error) {
+		t, _ := extract(token)
+		return t, nil
+	})
+}
+
+// Between returns a Rule matching a non-zero time in the range [from, to).
+func Between(from, to time.Time) Rule[time.Time] {
+	return func(t time.Time) (bool, error) {
+		return !t.IsZero() && !t.Before(from) && t.Before(to), nil
+	}
+}
+
+//--------------------------------------------------------------------------------
+
+type timeWindowScanner struct {
+	TypedScanner[time.Time]
+	from, to time.Time
+	inWindow bool
+	done     bool
+}
+
+// NewTimeWindowScanner returns a TypedScanner that outputs tokens with timestamps in the range [from, to),
+// for a log with non-decreasing timestamps.
+// Tokens without a timestamp belong to the previous token with one, like stack trace lines do.
+// The scanning stops at the first timestamp at or after the end of the window, the rest of input is not read.
+func NewTimeWindowScanner(sc TypedScanner[time.Time], from, to time.Time) TypedScanner[time.Time] {
+	return TypedScanner[time.Time](&timeWindowScanner{
+		TypedScanner: sc,
+		from:         from,
+		to:           to,
+	})
+}
+
+func (sc *timeWindowScanner) Scan() bool {
+	for !sc.done && sc.TypedScanner.Scan() {
+		t := sc.TypedScanner.Value()
+		if t.IsZero() {
+			if sc.inWindow {
+				return true
+			}
+			continue
+		}
+		if !t.Before(sc.to) {
+			break
+		}
+		sc.inWindow = !t.Before(sc.from)
+		if sc.inWindow {
+			return true
+		}
+	}
+	sc.done, sc.inWindow = true, false
+	return false
+}
+
+func (sc *timeWindowScanner) IsMatch() bool {
+	return sc.inWindow && sc.TypedScanner.IsMatch()
+}
+
+func (sc *timeWindowScanner) Text() string {
+	if sc.done {
+		return ""
+	}
+	return sc.TypedScanner.Text()
+}
+
+func (sc *timeWindowScanner) Bytes() []byte {
+	if sc.done {
+		return nil
+	}
+	return sc.TypedScanner.Bytes()
+}
+
+func (sc *timeWindowScanner) Value() time.Time {
+	if sc.done {
+		return time.Time{}
+	}
+	return sc.TypedScanner.Value()
+}
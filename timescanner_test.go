@@ -0,0 +1,93 @@
+package scanio_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func TestAutoTimeExtractor(t *testing.T) {
+	extract := scanio.AutoTimeExtractor(2023, nil)
+	tests := map[string]string{
+		"2023-03-04T05:06:07Z INFO x":                                 "2023-03-04T05:06:07Z",
+		"ts=2023-03-04T05:06:07.5+02:00 x":                            "2023-03-04T03:06:07.5Z",
+		"2023-03-04 05:06:07 INFO x":                                  "2023-03-04T05:06:07Z",
+		`127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0"`: "2000-10-10T20:55:36Z",
+		"Jan  5 10:00:00 host sshd[1]: ok":                            "2023-01-05T10:00:00Z",
+		"Dec 25 23:59:59 host x":                                      "2023-12-25T23:59:59Z",
+		"no time here":                                                "",
+	}
+	for input, want := range tests {
+		tm, ok := extract([]byte(input))
+		got := ""
+		if ok {
+			got = tm.UTC().Format(time.RFC3339Nano)
+		}
+		if got != want {
+			t.Errorf("%q: should be %v, is %v", input, want, got)
+		}
+	}
+}
+
+func TestLayoutTimeExtractor(t *testing.T) {
+	extract := scanio.LayoutTimeExtractor("2006/01/02 15:04", time.UTC)
+	tm, ok := extract([]byte("2023/03/04 05:06 message"))
+	if !ok || !tm.Equal(time.Date(2023, 3, 4, 5, 6, 0, 0, time.UTC)) {
+		t.Errorf("should be %v, is %v, %v", "2023-03-04 05:06", tm, ok)
+	}
+	if _, ok := extract([]byte("message 2023/03/04 05:06")); ok {
+		t.Errorf("should not find a time in the middle")
+	}
+}
+
+const timedLog = `2023-01-01T10:00:00Z a
+2023-01-01T11:00:00Z b
+  continuation of b
+2023-01-01T12:00:00Z c
+2023-01-01T13:00:00Z d
+2023-01-01T11:30:00Z out of order`
+
+func TestTimeScannerBetween(t *testing.T) {
+	from := time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC)
+	scn := scanio.NewTypedFilterScanner(
+		scanio.NewTimeScanner(scanio.NewScanner(strings.NewReader(timedLog)), scanio.AutoTimeExtractor(0, nil)),
+		scanio.Between(from, to))
+
+	var got []int
+	for scn.Scan() {
+		got = append(got, scn.NumRead())
+	}
+	if len(got) != 3 || got[0] != 2 || got[1] != 4 || got[2] != 6 {
+		t.Errorf("should be %v, is %v", []int{2, 4, 6}, got)
+	}
+}
+
+func TestTimeWindowScanner(t *testing.T) {
+	from := time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC)
+	scn := scanio.NewTimeWindowScanner(
+		scanio.NewTimeScanner(scanio.NewScanner(strings.NewReader(timedLog)), scanio.AutoTimeExtractor(0, nil)),
+		from, to)
+
+	expected := []result{
+		{true, 2, true, "2023-01-01T11:00:00Z b"},
+		{true, 3, true, "  continuation of b"},
+		{true, 4, true, "2023-01-01T12:00:00Z c"},
+		// stops at "d", the out-of-order line is not read
+		{false, 5, false, ""},
+		{false, 5, false, ""},
+	}
+	for _, v := range expected {
+		res, num, isMatch, text := scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Text()
+
+		if res != v.canParse || num != v.num || isMatch != v.isMatch || text != v.text {
+			t.Errorf("should be %v, is %v", v, result{res, num, isMatch, text})
+		}
+	}
+	if !scn.Value().IsZero() {
+		t.Errorf("should be a zero time, is %v", scn.Value())
+	}
+}
@@ -0,0 +1,176 @@
+package scanio
+
+// ParseFunc converts a token into a value of the type T.
+type ParseFunc[T any] func(token []byte) (T, error)
+
+// Rule is a MatchRule for parsed values.
+type Rule[T any] func(value T) (matched bool, err error)
+
+// TypedScanner parses each token once, at scan time.
+// A parse error stops the scanning, as a MatchRule error does.
+type TypedScanner[T any] interface {
+	Scanner
+	Value() T // parsed value of the current token
+}
+
+type typedScanner[T any] struct {
+	Scanner
+	parse ParseFunc[T]
+	value T
+	err   error
+}
+
+// NewTypedScanner creates a new TypedScanner, tokens are parsed by the parse function.
+func NewTypedScanner[T any](sc Scanner, parse ParseFunc[T]) TypedScanner[T] {
+	return TypedScanner[T](&typedScanner[T]{
+		Scanner: sc,
+		parse:   parse,
+	})
+}
+
+func (sc *typedScanner[T]) Scan() bool {
+	var zero T
+	sc.value = zero
+	if sc.err != nil || !sc.Scanner.Scan() {
+		return false
+	}
+	sc.value, sc.err = sc.parse(sc.Scanner.Bytes())
+	if sc.err != nil {
+		sc.value = zero
+		return false
+	}
+	return true
+}
+
+func (sc *typedScanner[T]) Value() T {
+	return sc.value
+}
+
+func (sc *typedScanner[T]) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
+	return sc.Scanner.Err()
+}
+
+//--------------------------------------------------------------------------------
+
+type typedRuleScanner[T any] struct {
+	TypedScanner[T]
+	rule    Rule[T]
+	matched bool
+	err     error
+}
+
+// NewTypedRuleScanner returns a new TypedScanner, its IsMatch is set by the rule applied to the parsed value.
+func NewTypedRuleScanner[T any](sc TypedScanner[T], rule Rule[T]) TypedScanner[T] {
+	return TypedScanner[T](&typedRuleScanner[T]{
+		TypedScanner: sc,
+		rule:         rule,
+	})
+}
+
+func (sc *typedRuleScanner[T]) Scan() bool {
+	sc.matched = false
+	if sc.err != nil || !sc.TypedScanner.Scan() {
+		return false
+	}
+	sc.matched, sc.err = sc.rule(sc.TypedScanner.Value())
+	if sc.err != nil {
+		sc.matched = false
+		return false
+	}
+	return true
+}
+
+func (sc *typedRuleScanner[T]) IsMatch() bool {
+	return sc.matched
+}
+
+func (sc *typedRuleScanner[T]) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
+	return sc.TypedScanner.Err()
+}
+
+type typedOnlyMatchScanner[T any] struct {
+	TypedScanner[T]
+}
+
+// NewTypedOnlyMatchScanner returns a new TypedScanner, which outputs only matching tokens.
+func NewTypedOnlyMatchScanner[T any](sc TypedScanner[T]) TypedScanner[T] {
+	return TypedScanner[T](&typedOnlyMatchScanner[T]{
+		TypedScanner: sc,
+	})
+}
+
+func (sc *typedOnlyMatchScanner[T]) Scan() bool {
+	for sc.TypedScanner.Scan() {
+		if sc.TypedScanner.IsMatch() {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTypedFilterScanner creates a TypedScanner that outputs only tokens whose values match the rule.
+func NewTypedFilterScanner[T any](sc TypedScanner[T], rule Rule[T]) TypedScanner[T] {
+	return NewTypedOnlyMatchScanner(NewTypedRuleScanner(sc, rule))
+}
+
+//--------------------------------------------------------------------------------
+
+// TypedAheadScanner is an AheadScanner for parsed values.
+type TypedAheadScanner[T any] interface {
+	AheadScanner
+	Value() T // parsed value of the current token
+}
+
+// valueQueue records values of a TypedScanner, as they are read ahead.
+type valueQueue[T any] struct {
+	TypedScanner[T]
+	values []T
+}
+
+func (q *valueQueue[T]) Scan() bool {
+	var v T
+	res := q.TypedScanner.Scan()
+	if res {
+		v = q.TypedScanner.Value()
+	}
+	q.values = append(q.values, v)
+	return res
+}
+
+type typedAheadScanner[T any] struct {
+	AheadScanner
+	queue   *valueQueue[T]
+	started bool
+}
+
+// NewTypedAheadScanner creates a new TypedAheadScanner.
+func NewTypedAheadScanner[T any](sc TypedScanner[T]) TypedAheadScanner[T] {
+	q := &valueQueue[T]{TypedScanner: sc}
+	return TypedAheadScanner[T](&typedAheadScanner[T]{
+		AheadScanner: NewAheadScanner(q),
+		queue:        q,
+	})
+}
+
+func (sc *typedAheadScanner[T]) Scan() bool {
+	if sc.started {
+		// drop the previous value
+		sc.queue.values = sc.queue.values[1:]
+	}
+	sc.started = true
+	return sc.AheadScanner.Scan()
+}
+
+func (sc *typedAheadScanner[T]) Value() T {
+	if len(sc.queue.values) == 0 {
+		var zero T
+		return zero
+	}
+	return sc.queue.values[0]
+}
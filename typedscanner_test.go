@@ -0,0 +1,133 @@
+package scanio_test
+
+import (
+	"bufio"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomaskraus/scanio"
+)
+
+func parseInt(b []byte) (int, error) {
+	return strconv.Atoi(string(b))
+}
+
+func isPositive(v int) (bool, error) {
+	return v > 0, nil
+}
+
+type resultT struct {
+	canParse bool
+	num      int
+	isMatch  bool
+	value    int
+}
+
+func TestTypedScanner(t *testing.T) {
+	scn := scanio.NewTypedScanner(scanio.NewScanner(strings.NewReader("12 -3 x 4")), parseInt)
+	scn.Split(bufio.ScanWords)
+
+	expected := []resultT{
+		{true, 1, true, 12},
+		{true, 2, true, -3},
+		{false, 3, true, 0},
+		{false, 3, true, 0},
+	}
+	for _, v := range expected {
+		r := resultT{scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Value()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+	var numErr *strconv.NumError
+	if !errors.As(scn.Err(), &numErr) {
+		t.Errorf("should be a parse error, is %v", scn.Err())
+	}
+}
+
+func TestTypedFilterScanner(t *testing.T) {
+	scn := scanio.NewTypedFilterScanner(
+		scanio.NewTypedScanner(scanio.NewScanner(strings.NewReader("12 -3 0 4 -1")), parseInt),
+		isPositive)
+	scn.Split(bufio.ScanWords)
+
+	expected := []resultT{
+		{true, 1, true, 12},
+		{true, 4, true, 4},
+		{false, 5, false, 0},
+	}
+	for _, v := range expected {
+		r := resultT{scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Value()}
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestTypedRuleScannerError(t *testing.T) {
+	errRule := errors.New("rule error")
+	scn := scanio.NewTypedRuleScanner(
+		scanio.NewTypedScanner(scanio.NewScanner(strings.NewReader("1\n2\n3")), parseInt),
+		func(v int) (bool, error) {
+			if v == 2 {
+				return false, errRule
+			}
+			return true, nil
+		})
+
+	count := 0
+	for scn.Scan() {
+		count++
+	}
+	if count != 1 || scn.Err() != errRule {
+		t.Errorf("should be %v, %v, is %v, %v", 1, errRule, count, scn.Err())
+	}
+}
+
+func TestTypedAheadScanner(t *testing.T) {
+	scn := scanio.NewTypedAheadScanner(scanio.NewTypedRuleScanner(
+		scanio.NewTypedScanner(scanio.NewScanner(strings.NewReader("5 7 -1 3")), parseInt),
+		isPositive))
+	scn.Split(bufio.ScanWords)
+
+	expected := []struct {
+		resultT
+		isLast, consecEnd bool
+	}{
+		{resultT{true, 1, true, 5}, false, false},
+		{resultT{true, 2, true, 7}, false, true},
+		{resultT{true, 3, false, -1}, false, false},
+		{resultT{true, 4, true, 3}, true, true},
+		{resultT{false, 4, false, 0}, true, false},
+		{resultT{false, 4, false, 0}, true, false},
+	}
+	for _, v := range expected {
+		r := resultT{scn.Scan(), scn.NumRead(), scn.IsMatch(), scn.Value()}
+		if r != v.resultT || scn.IsLast() != v.isLast || scn.IsConsecutiveEnd() != v.consecEnd {
+			t.Errorf("should be %v, is %v %v %v", v, r, scn.IsLast(), scn.IsConsecutiveEnd())
+		}
+	}
+}
+
+func TestTypedScannerTime(t *testing.T) {
+	parseDate := func(b []byte) (time.Time, error) {
+		return time.Parse("2006-01-02", string(b))
+	}
+	since := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	scn := scanio.NewTypedFilterScanner(
+		scanio.NewTypedScanner(scanio.NewScanner(strings.NewReader("2023-01-15\n2023-07-01\n2023-05-31\n2023-06-01")), parseDate),
+		func(v time.Time) (bool, error) {
+			return !v.Before(since), nil
+		})
+
+	var got []string
+	for scn.Scan() {
+		got = append(got, scn.Value().Format("Jan 2"))
+	}
+	if strings.Join(got, ",") != "Jul 1,Jun 1" {
+		t.Errorf("should be %v, is %v", "Jul 1,Jun 1", got)
+	}
+}
@@ -0,0 +1,165 @@
+package scanio
+
+// WindowRule for NewWindowScanner. Tells whether the window of tokens matches.
+type WindowRule func(window [][]byte) (matched bool, err error)
+
+// MinMatchWindowRule returns a WindowRule that matches if at least min tokens of the window match the rule.
+func MinMatchWindowRule(rule MatchRule, min int) WindowRule {
+	return func(window [][]byte) (bool, error) {
+		count := 0
+		for _, tok := range window {
+			matched, err := rule(tok)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				count++
+				if count >= min {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+}
+
+// WindowScanner slides a window of consecutive tokens over its input.
+// The current token is the newest token of the window, IsMatch tells whether the whole window matches.
+// NumRead counts the windows, so adjacent matching windows form a consecutive sequence for the AheadScanner,
+// whatever the step is.
+type WindowScanner interface {
+	Scanner
+	Window() [][]byte  // tokens of the current window, oldest first
+	FirstNumRead() int // NumRead of the oldest token in the window
+	LastNumRead() int  // NumRead of the newest token in the window
+}
+
+type windowScanner struct {
+	Scanner
+	size, step int
+	rule       WindowRule
+	window     [][]byte
+	nums       []int
+	match      bool
+	num        int
+	err        error
+	started    bool
+}
+
+// NewWindowScanner creates a new WindowScanner with windows of size tokens, moved by step tokens.
+// Only full windows are output, an input shorter than size gives no window.
+// The rule can be nil, each window matches then.
+func NewWindowScanner(sc Scanner, size, step int, rule WindowRule) WindowScanner {
+	if size < 1 {
+		size = 1
+	}
+	if step < 1 {
+		step = 1
+	}
+	return WindowScanner(&windowScanner{
+		Scanner: sc,
+		size:    size,
+		step:    step,
+		rule:    rule,
+	})
+}
+
+// next reads one more token into the window.
+func (sc *windowScanner) next() bool {
+	if !sc.Scanner.Scan() {
+		return false
+	}
+	sc.window = append(sc.window, append([]byte(nil), sc.Scanner.Bytes()...))
+	sc.nums = append(sc.nums, sc.Scanner.NumRead())
+	return true
+}
+
+func (sc *windowScanner) Scan() bool {
+	sc.match = false
+	if sc.err != nil {
+		return false
+	}
+	toRead := sc.step
+	if !sc.started {
+		sc.started = true
+		toRead = sc.size
+	} else if sc.window != nil {
+		// windows already returned stay intact
+		drop := sc.step
+		if drop > len(sc.window) {
+			drop = len(sc.window)
+		}
+		sc.window = append([][]byte(nil), sc.window[drop:]...)
+		sc.nums = append([]int(nil), sc.nums[drop:]...)
+		// the step is longer than the window
+		for skip := sc.step - drop; skip > 0; skip-- {
+			if !sc.Scanner.Scan() {
+				sc.window, sc.nums = nil, nil
+				return false
+			}
+		}
+		toRead = sc.size - len(sc.window)
+	}
+	for ; toRead > 0; toRead-- {
+		if !sc.next() {
+			sc.window, sc.nums = nil, nil
+			return false
+		}
+	}
+	if sc.rule == nil {
+		sc.match = true
+		sc.num++
+		return true
+	}
+	sc.match, sc.err = sc.rule(sc.window)
+	if sc.err != nil {
+		sc.match = false
+		return false
+	}
+	sc.num++
+	return true
+}
+
+func (sc *windowScanner) Window() [][]byte {
+	return sc.window
+}
+
+func (sc *windowScanner) FirstNumRead() int {
+	if len(sc.nums) == 0 {
+		return 0
+	}
+	return sc.nums[0]
+}
+
+func (sc *windowScanner) LastNumRead() int {
+	if len(sc.nums) == 0 {
+		return 0
+	}
+	return sc.nums[len(sc.nums)-1]
+}
+
+func (sc *windowScanner) NumRead() int {
+	return sc.num
+}
+
+func (sc *windowScanner) Bytes() []byte {
+	if len(sc.window) == 0 {
+		return nil
+	}
+	return sc.window[len(sc.window)-1]
+}
+
+func (sc *windowScanner) Text() string {
+	return string(sc.Bytes())
+}
+
+func (sc *windowScanner) IsMatch() bool {
+	return sc.match
+}
+
+func (sc *windowScanner) Err() error {
+	if sc.err != nil {
+		return sc.err
+	}
+	return sc.Scanner.Err()
+}
@@ -0,0 +1,163 @@
+package scanio_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+type resultW struct {
+	canParse bool
+	first    int
+	last     int
+	num      int
+	isMatch  bool
+	window   string
+}
+
+func windowResult(sc scanio.WindowScanner, res bool) resultW {
+	return resultW{res, sc.FirstNumRead(), sc.LastNumRead(), sc.NumRead(), sc.IsMatch(), fmt.Sprintf("%s", sc.Window())}
+}
+
+func TestWindowScanner(t *testing.T) {
+	scn := scanio.NewWindowScanner(scanio.NewScanner(strings.NewReader("1 2 3 4 5")), 3, 1, nil)
+	scn.Split(bufio.ScanWords)
+
+	expected := []resultW{
+		{true, 1, 3, 1, true, "[1 2 3]"},
+		{true, 2, 4, 2, true, "[2 3 4]"},
+		{true, 3, 5, 3, true, "[3 4 5]"},
+		{false, 0, 0, 3, false, "[]"},
+		{false, 0, 0, 3, false, "[]"},
+	}
+	for _, v := range expected {
+		r := windowResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestWindowScannerStep(t *testing.T) {
+	scn := scanio.NewWindowScanner(scanio.NewScanner(strings.NewReader("1 2 3 4 5 6")), 3, 2, nil)
+	scn.Split(bufio.ScanWords)
+
+	expected := []resultW{
+		{true, 1, 3, 1, true, "[1 2 3]"},
+		{true, 3, 5, 2, true, "[3 4 5]"},
+		{false, 0, 0, 2, false, "[]"},
+	}
+	for _, v := range expected {
+		r := windowResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestWindowScannerLongStep(t *testing.T) {
+	scn := scanio.NewWindowScanner(scanio.NewScanner(strings.NewReader("1 2 3 4 5 6 7")), 2, 4, nil)
+	scn.Split(bufio.ScanWords)
+
+	expected := []resultW{
+		{true, 1, 2, 1, true, "[1 2]"},
+		{true, 5, 6, 2, true, "[5 6]"},
+		{false, 0, 0, 2, false, "[]"},
+	}
+	for _, v := range expected {
+		r := windowResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestWindowScannerShortInput(t *testing.T) {
+	scn := scanio.NewWindowScanner(scanio.NewScanner(strings.NewReader("1 2")), 3, 1, nil)
+	scn.Split(bufio.ScanWords)
+
+	expected := []resultW{
+		{false, 0, 0, 0, false, "[]"},
+		{false, 0, 0, 0, false, "[]"},
+	}
+	for _, v := range expected {
+		r := windowResult(scn, scn.Scan())
+		if r != v {
+			t.Errorf("should be %v, is %v", v, r)
+		}
+	}
+}
+
+func TestWindowScannerConsecutive(t *testing.T) {
+	const input = "ok\nERROR\nok\nERROR\nERROR\nok\nok\nok"
+	isError := func(b []byte) (bool, error) {
+		return bytes.Contains(b, []byte("ERROR")), nil
+	}
+	// any 3 consecutive lines where 2 contain ERROR
+	scn := scanio.NewAheadScanner(
+		scanio.NewWindowScanner(
+			scanio.NewScanner(strings.NewReader(input)), 3, 1, scanio.MinMatchWindowRule(isError, 2)))
+
+	expected := []bool{false, true, true, true, false, false}
+	for i, v := range expected {
+		scn.Scan()
+		if scn.IsMatch() != v {
+			t.Errorf("at %d: should be %v, is %v", i, v, scn.IsMatch())
+		}
+		if scn.IsConsecutiveEnd() && scn.NumConsecutive() != 3 {
+			t.Errorf("at %d: should be %v, is %v", i, 3, scn.NumConsecutive())
+		}
+	}
+}
+
+func TestWindowScannerConsecutiveStep(t *testing.T) {
+	const input = "ok\nERROR\nERROR\nERROR\nERROR\nERROR\nERROR\nERROR\nok\nok\nok"
+	isError := func(b []byte) (bool, error) {
+		return bytes.Contains(b, []byte("ERROR")), nil
+	}
+	// windows of lines 1-3, 3-5, 5-7, 7-9, 9-11
+	scn := scanio.NewAheadScanner(
+		scanio.NewWindowScanner(
+			scanio.NewScanner(strings.NewReader(input)), 3, 2, scanio.MinMatchWindowRule(isError, 2)))
+
+	expected := []struct {
+		isMatch, isEnd bool
+		numConsecutive int
+	}{
+		{true, false, 1},
+		{true, false, 2},
+		{true, false, 3},
+		{true, true, 4},
+		{false, false, 0},
+	}
+	for i, v := range expected {
+		scn.Scan()
+		if scn.IsMatch() != v.isMatch || scn.IsConsecutiveEnd() != v.isEnd || scn.NumConsecutive() != v.numConsecutive {
+			t.Errorf("at %d: should be %v, is %v %v %v", i, v, scn.IsMatch(), scn.IsConsecutiveEnd(), scn.NumConsecutive())
+		}
+	}
+}
+
+func TestWindowScannerError(t *testing.T) {
+	errRule := errors.New("rule error")
+	scn := scanio.NewWindowScanner(scanio.NewScanner(strings.NewReader("1\n2\n3")), 2, 1,
+		func(w [][]byte) (bool, error) {
+			if string(w[1]) == "3" {
+				return false, errRule
+			}
+			return true, nil
+		})
+
+	count := 0
+	for scn.Scan() {
+		count++
+	}
+	if count != 1 || scn.Err() != errRule {
+		t.Errorf("should be %v, %v, is %v, %v", 1, errRule, count, scn.Err())
+	}
+}
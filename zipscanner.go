@@ -0,0 +1,161 @@
+package scanio
+
+import (
+	"bufio"
+	"bytes"
+)
+
+// ZipSide tells which side of the ZipScanner ended first.
+type ZipSide int
+
+const (
+	ZipNone  ZipSide = iota // neither side ended first (yet)
+	ZipLeft                 // the left side ended first
+	ZipRight                // the right side ended first
+)
+
+// ZipRule for NewZipScanner, matching a pair of tokens.
+type ZipRule func(left, right []byte) (matched bool, err error)
+
+// EqualRule is a ZipRule matching equal tokens.
+func EqualRule(left, right []byte) (bool, error) {
+	return bytes.Equal(left, right), nil
+}
+
+// ZipScanner scans two Scanners in lockstep, a pair of their tokens at a time.
+type ZipScanner interface {
+	Scanner
+	Left() []byte        // current token of the left Scanner, nil if the left side has ended
+	Right() []byte       // current token of the right Scanner, nil if the right side has ended
+	HasLeft() bool       // true if there is a current token of the left Scanner
+	HasRight() bool      // true if there is a current token of the right Scanner
+	EndedFirst() ZipSide // the side that has ended before the other one
+}
+
+type zipScanner struct {
+	left, right           Scanner
+	rule                  ZipRule
+	hasLeft, hasRight     bool
+	leftEnded, rightEnded bool
+	endedFirst            ZipSide
+	match                 bool
+	num                   int
+	done                  bool
+	err                   error
+}
+
+// NewZipScanner creates a new ZipScanner. It scans until both Scanners end, so the extra tokens of the longer one
+// are scanned with the other side missing. IsMatch is the result of the rule for a pair of tokens,
+// it is false if one side is missing. NumRead counts the pairs.
+// Text and Bytes return the left token, or the right one if the left side has ended.
+// Scanning stops at the first error of any Scanner or the rule.
+func NewZipScanner(left, right Scanner, rule ZipRule) ZipScanner {
+	return ZipScanner(&zipScanner{
+		left:  left,
+		right: right,
+		rule:  rule,
+	})
+}
+
+// scanSide scans the next token of one side.
+func (sc *zipScanner) scanSide(s Scanner, ended *bool) bool {
+	if *ended {
+		return false
+	}
+	if s.Scan() {
+		return true
+	}
+	*ended = true
+	if err := s.Err(); err != nil && sc.err == nil {
+		sc.err = err
+	}
+	return false
+}
+
+func (sc *zipScanner) Scan() bool {
+	if sc.done {
+		return false
+	}
+	sc.hasLeft = sc.scanSide(sc.left, &sc.leftEnded)
+	sc.hasRight = sc.scanSide(sc.right, &sc.rightEnded)
+	sc.match = false
+	if sc.err != nil || (!sc.hasLeft && !sc.hasRight) {
+		sc.done, sc.hasLeft, sc.hasRight = true, false, false
+		return false
+	}
+	if sc.endedFirst == ZipNone {
+		if !sc.hasLeft {
+			sc.endedFirst = ZipLeft
+		} else if !sc.hasRight {
+			sc.endedFirst = ZipRight
+		}
+	}
+	sc.num++
+	if sc.hasLeft && sc.hasRight {
+		sc.match, sc.err = sc.rule(sc.left.Bytes(), sc.right.Bytes())
+		if sc.err != nil {
+			sc.done, sc.hasLeft, sc.hasRight, sc.match = true, false, false, false
+			return false
+		}
+	}
+	return true
+}
+
+func (sc *zipScanner) Buffer(buf []byte, max int) {
+	sc.left.Buffer(buf, max)
+	sc.right.Buffer(make([]byte, len(buf), cap(buf)), max)
+}
+
+func (sc *zipScanner) Split(split bufio.SplitFunc) {
+	sc.left.Split(split)
+	sc.right.Split(split)
+}
+
+func (sc *zipScanner) Left() []byte {
+	if !sc.hasLeft {
+		return nil
+	}
+	return sc.left.Bytes()
+}
+
+func (sc *zipScanner) Right() []byte {
+	if !sc.hasRight {
+		return nil
+	}
+	return sc.right.Bytes()
+}
+
+func (sc *zipScanner) HasLeft() bool {
+	return sc.hasLeft
+}
+
+func (sc *zipScanner) HasRight() bool {
+	return sc.hasRight
+}
+
+func (sc *zipScanner) EndedFirst() ZipSide {
+	return sc.endedFirst
+}
+
+func (sc *zipScanner) Bytes() []byte {
+	if sc.hasLeft {
+		return sc.left.Bytes()
+	}
+	return sc.Right()
+}
+
+func (sc *zipScanner) Text() string {
+	return string(sc.Bytes())
+}
+
+func (sc *zipScanner) Err() error {
+	return sc.err
+}
+
+func (sc *zipScanner) IsMatch() bool {
+	return sc.match
+}
+
+func (sc *zipScanner) NumRead() int {
+	return sc.num
+}
@@ -0,0 +1,103 @@
+package scanio_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tomaskraus/scanio"
+)
+
+type resultZ struct {
+	result
+	left, right string
+	endedFirst  scanio.ZipSide
+}
+
+func zipResult(scn scanio.ZipScanner) resultZ {
+	res := scanResult(scn)
+	return resultZ{res, string(scn.Left()), string(scn.Right()), scn.EndedFirst()}
+}
+
+func TestZipScanner(t *testing.T) {
+	scn := scanio.NewZipScanner(
+		scanio.NewScanner(strings.NewReader("a\nb\nc\n")),
+		scanio.NewScanner(strings.NewReader("a\nB\nc\nd\n\n")),
+		scanio.EqualRule)
+
+	expected := []resultZ{
+		{result{true, 1, true, "a"}, "a", "a", scanio.ZipNone},
+		{result{true, 2, false, "b"}, "b", "B", scanio.ZipNone},
+		{result{true, 3, true, "c"}, "c", "c", scanio.ZipNone},
+		{result{true, 4, false, "d"}, "", "d", scanio.ZipLeft},
+		// the missing side does not equal an empty token
+		{result{true, 5, false, ""}, "", "", scanio.ZipLeft},
+		{result{false, 5, false, ""}, "", "", scanio.ZipLeft},
+		{result{false, 5, false, ""}, "", "", scanio.ZipLeft},
+	}
+	for _, v := range expected {
+		if got := zipResult(scn); got != v {
+			t.Errorf("should be %v, is %v", v, got)
+		}
+	}
+}
+
+func TestZipScannerMismatches(t *testing.T) {
+	scn := scanio.NewZipScanner(
+		scanio.NewScanner(strings.NewReader("1\n2\n3\n4")),
+		scanio.NewScanner(strings.NewReader("1\n2\nx")),
+		scanio.EqualRule)
+	mismatches := scanio.NewOnlyNotMatchScanner(scn)
+
+	var got []int
+	for mismatches.Scan() {
+		got = append(got, mismatches.NumRead())
+	}
+	if len(got) != 2 || got[0] != 3 || got[1] != 4 || scn.EndedFirst() != scanio.ZipRight {
+		t.Errorf("should be %v, %v, is %v, %v", []int{3, 4}, scanio.ZipRight, got, scn.EndedFirst())
+	}
+}
+
+func TestZipScannerSameLength(t *testing.T) {
+	scn := scanio.NewZipScanner(
+		scanio.NewScanner(strings.NewReader("1\n2")),
+		scanio.NewScanner(strings.NewReader("1\n2\n")),
+		scanio.EqualRule)
+
+	n := 0
+	for scn.Scan() {
+		n++
+		if !scn.IsMatch() || !scn.HasLeft() || !scn.HasRight() {
+			t.Errorf("%d: should match, is %q, %q", n, scn.Left(), scn.Right())
+		}
+	}
+	if n != 2 || scn.EndedFirst() != scanio.ZipNone || scn.Err() != nil {
+		t.Errorf("should be %d, %v, is %d, %v, %v", 2, scanio.ZipNone, n, scn.EndedFirst(), scn.Err())
+	}
+}
+
+func TestZipScannerRuleError(t *testing.T) {
+	errRule := errors.New("rule error")
+	scn := scanio.NewZipScanner(
+		scanio.NewScanner(strings.NewReader("1\n2")),
+		scanio.NewScanner(strings.NewReader("1\n2")),
+		func(left, right []byte) (bool, error) {
+			if string(left) == "2" {
+				return false, errRule
+			}
+			return true, nil
+		})
+
+	expected := []resultZ{
+		{result{true, 1, true, "1"}, "1", "1", scanio.ZipNone},
+		{result{false, 2, false, ""}, "", "", scanio.ZipNone},
+	}
+	for _, v := range expected {
+		if got := zipResult(scn); got != v {
+			t.Errorf("should be %v, is %v", v, got)
+		}
+	}
+	if scn.Err() != errRule {
+		t.Errorf("should be %v, is %v", errRule, scn.Err())
+	}
+}